@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,15 +38,36 @@ type csvColumn struct {
 }
 
 type exportedStatisticsService struct {
-	Name       string `json:"name"`
-	Executions int    `json:"Executions"`
-	EventType  string `json:"eventType"`
+	Name       string         `json:"name"`
+	Executions int            `json:"Executions"`
+	EventType  string         `json:"eventType"`
+	Results    map[string]int `json:"results,omitempty"`
+	Statuses   map[string]int `json:"statuses,omitempty"`
+}
+
+type exportedApprovals struct {
+	Requested            int     `json:"requested"`
+	Approved             int     `json:"approved"`
+	Rejected             int     `json:"rejected"`
+	Automatic            int     `json:"automatic"`
+	Manual               int     `json:"manual"`
+	AverageTimeToApprove float64 `json:"averageTimeToApprove"`
+}
+
+type exportedTrigger struct {
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Category string `json:"category"`
+	Count    int    `json:"count"`
 }
 
 type exportedStatisticsSummary struct {
 	Granularity       string                      `json:"granularity"`
 	Executions        int                         `json:"executions"`
 	ServiceExecutions []exportedStatisticsService `json:"serviceExecutions"`
+	Approvals         *exportedApprovals          `json:"approvals,omitempty"`
+	Triggers          int                         `json:"triggers"`
+	TriggersByType    []exportedTrigger           `json:"triggersByType,omitempty"`
 	Projects          []exportedStatisticsSummary `json:"projects,omitempty"`
 	Services          []exportedStatisticsSummary `json:"services,omitempty"`
 }
@@ -69,10 +91,23 @@ type statistics struct {
 	triggers               int
 	triggersByType         map[string]*triggerExecution
 	subStatistics          map[string]*statistics
+	approvals              *approvalStatistics
+}
+
+type approvalStatistics struct {
+	requested          int
+	approved           int
+	rejected           int
+	automatic          int
+	manual             int
+	timeToApproveCount int
+	timeToApproveSum   float64
 }
 
 type keptnServiceExecution struct {
 	eventTypeCount map[string]int
+	resultCount    map[string]map[string]int
+	statusCount    map[string]map[string]int
 }
 
 type triggerExecution struct {
@@ -134,7 +169,7 @@ keptn-usage-stats
    --folder=./usage-statistics-xyz 
    --period=separated
    --granularity=overall,project 
-   --includeEvents=deployment-finished,tests-finished,evaluation-done 
+   --includeEvents=deployment,test,evaluation 
    --includeServices=all`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := checkPeriod(); err != nil {
@@ -497,6 +532,8 @@ func exportToJSON(s *statisticsOutput, index int) {
 	}
 
 	appendKeptnServiceExecutions(&s.overallStatistics, &result.Summary)
+	result.Summary.Approvals = exportApprovals(s.overallStatistics.approvals)
+	exportTriggers(&s.overallStatistics, &result.Summary)
 
 	if isProjectGranularity() {
 		for _, project := range s.perProjectStatistics {
@@ -509,6 +546,8 @@ func exportToJSON(s *statisticsOutput, index int) {
 			}
 
 			appendKeptnServiceExecutions(project, &newExportedProjectStats)
+			newExportedProjectStats.Approvals = exportApprovals(project.approvals)
+			exportTriggers(project, &newExportedProjectStats)
 
 			if isServiceGranularity() {
 				for _, svc := range project.subStatistics {
@@ -521,6 +560,8 @@ func exportToJSON(s *statisticsOutput, index int) {
 					}
 
 					appendKeptnServiceExecutions(svc, &newExportedServiceStats)
+					newExportedServiceStats.Approvals = exportApprovals(svc.approvals)
+					exportTriggers(svc, &newExportedServiceStats)
 					newExportedProjectStats.Services = append(newExportedProjectStats.Services, newExportedServiceStats)
 				}
 			}
@@ -552,6 +593,8 @@ func appendKeptnServiceExecutions(s *statistics, summary *exportedStatisticsSumm
 				Name:       keptnServiceName,
 				Executions: count,
 				EventType:  eventType,
+				Results:    serviceExecution.resultCount[eventType],
+				Statuses:   serviceExecution.statusCount[eventType],
 			}
 
 			summary.ServiceExecutions = append(summary.ServiceExecutions, keptnServiceExecs)
@@ -559,6 +602,39 @@ func appendKeptnServiceExecutions(s *statistics, summary *exportedStatisticsSumm
 	}
 }
 
+func exportApprovals(approvals *approvalStatistics) *exportedApprovals {
+	if approvals == nil {
+		return nil
+	}
+	return &exportedApprovals{
+		Requested:            approvals.requested,
+		Approved:             approvals.approved,
+		Rejected:             approvals.rejected,
+		Automatic:            approvals.automatic,
+		Manual:               approvals.manual,
+		AverageTimeToApprove: approvals.averageTimeToApprove(),
+	}
+}
+
+func exportTriggers(s *statistics, summary *exportedStatisticsSummary) {
+	summary.Triggers = s.triggers
+	for _, trigger := range s.triggersByType {
+		summary.TriggersByType = append(summary.TriggersByType, exportedTrigger{
+			Type:     trigger.eventType,
+			Source:   trigger.name,
+			Category: stats.GetTriggerCategory(trigger.name, trigger.eventType),
+			Count:    trigger.count,
+		})
+	}
+}
+
+func (a *approvalStatistics) averageTimeToApprove() float64 {
+	if a.timeToApproveCount == 0 {
+		return 0
+	}
+	return a.timeToApproveSum / float64(a.timeToApproveCount)
+}
+
 func printStats(s *statisticsOutput) {
 	fmt.Println("---")
 	fmt.Println("Timeframe: " + s.from.String() + " - " + s.to.String())
@@ -584,12 +660,50 @@ func printSubStats(s *statistics) {
 	fmt.Println("-------------------------------------------------")
 	for keptnService, executions := range s.keptnServiceExecutions {
 		for eventType, execution := range executions.eventTypeCount {
-			fmt.Println(fmt.Sprintf("- %s: \t\t %d \t %s", keptnService, execution, eventType))
+			fmt.Println(fmt.Sprintf("- %s: \t\t %d \t %s%s", keptnService, execution, eventType, formatTaskResults(executions, eventType)))
+		}
+	}
+	if s.approvals != nil {
+		fmt.Println(fmt.Sprintf("- Approvals: \t\t %d requested, %d approved, %d rejected (%d automatic, %d manual), average time to approve: %.1fs",
+			s.approvals.requested, s.approvals.approved, s.approvals.rejected, s.approvals.automatic, s.approvals.manual, s.approvals.averageTimeToApprove()))
+	}
+	if s.triggers > 0 {
+		fmt.Println(fmt.Sprintf("- Triggers: 		 %d", s.triggers))
+		for _, trigger := range s.triggersByType {
+			fmt.Println(fmt.Sprintf("- %s: 		 %d 	 %s (%s)", trigger.name, trigger.count, trigger.eventType, stats.GetTriggerCategory(trigger.name, trigger.eventType)))
 		}
 	}
 	fmt.Println("")
 }
 
+// formatTaskResults returns the results and statuses of finished events for the given event type, e.g. " (pass: 2, fail: 1; succeeded: 3)"
+func formatTaskResults(execution *keptnServiceExecution, eventType string) string {
+	results := formatCounts(execution.resultCount[eventType])
+	statuses := formatCounts(execution.statusCount[eventType])
+	if results == "" && statuses == "" {
+		return ""
+	} else if results == "" {
+		return " (" + statuses + ")"
+	} else if statuses == "" {
+		return " (" + results + ")"
+	}
+	return " (" + results + "; " + statuses + ")"
+}
+
+func formatCounts(counts map[string]int) string {
+	keys := []string{}
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	formatted := []string{}
+	for _, key := range keys {
+		formatted = append(formatted, fmt.Sprintf("%s: %d", key, counts[key]))
+	}
+	return strings.Join(formatted, ", ")
+}
+
 func createAggregatedStatistics(statisticsFiles map[string]*stats.GetStatisticsResponse) []*statisticsOutput {
 	statsOutput := &statisticsOutput{
 		overallStatistics: statistics{
@@ -660,9 +774,19 @@ func createSeparatedStatistics(statisticsFiles map[string]*stats.GetStatisticsRe
 	return result
 }
 
-func mergeStatisticsResponseIntoStatisticsOutput(stats *stats.GetStatisticsResponse, statsOutput *statisticsOutput, rowIndex int) {
-	for _, project := range stats.Projects {
-		if len(excludeProjectsArr) > 0 && contains(excludeProjectsArr, project.Name) {
+func mergeStatisticsResponseIntoStatisticsOutput(statisticsResponse *stats.GetStatisticsResponse, statsOutput *statisticsOutput, rowIndex int) {
+	options := stats.SummaryOptions{
+		IncludeEvents:   includeEventsArr,
+		IncludeServices: includeServicesArr,
+		ExcludeProjects: excludeProjectsArr,
+	}
+	// the automation units are computed by the same implementation as the summary endpoint of the statistics-service
+	summary := stats.NewSummary(options)
+	summary.Add(*statisticsResponse)
+	addSummaryGroup(&statsOutput.overallStatistics, summary.Overall)
+
+	for _, project := range statisticsResponse.Projects {
+		if !options.IncludesProject(project.Name) {
 			continue
 		}
 
@@ -680,6 +804,7 @@ func mergeStatisticsResponseIntoStatisticsOutput(stats *stats.GetStatisticsRespo
 				}
 
 			}
+			addSummaryGroup(statsOutput.perProjectStatistics[project.Name], summary.Projects[project.Name])
 		}
 		for _, svc := range project.Services {
 
@@ -696,50 +821,42 @@ func mergeStatisticsResponseIntoStatisticsOutput(stats *stats.GetStatisticsRespo
 						subStatistics:          nil,
 					}
 				}
+				addSummaryGroup(statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name], summary.Services[project.Name][svc.Name])
+			}
+			if svc.Approvals != nil {
+				addApprovals(&statsOutput.overallStatistics, svc.Approvals)
+				if isProjectGranularity() {
+					addApprovals(statsOutput.perProjectStatistics[project.Name], svc.Approvals)
+					if isServiceGranularity() {
+						addApprovals(statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name], svc.Approvals)
+					}
+				}
+			}
+			for _, trigger := range svc.Triggers {
+				if len(includeTriggersArr) > 0 && !contains(includeTriggersArr, trigger.Type) {
+					continue
+				}
+				addTrigger(&statsOutput.overallStatistics, trigger)
+				if isProjectGranularity() {
+					addTrigger(statsOutput.perProjectStatistics[project.Name], trigger)
+					if isServiceGranularity() {
+						addTrigger(statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name], trigger)
+					}
+				}
 			}
 			for _, execution := range svc.KeptnServiceExecutions {
-
-				if len(includeServicesArr) > 0 && !contains(includeServicesArr, execution.Name) {
+				if !options.IncludesKeptnService(execution.Name) {
 					continue
 				}
-				for _, eventTypeExecution := range execution.Executions {
-
-					if len(includeEventsArr) > 0 && !contains(includeEventsArr, eventTypeExecution.Type) {
+				for _, taskResults := range execution.TaskResults {
+					if !options.IncludesEvent(taskResults.Type) {
 						continue
 					}
-					statsOutput.overallStatistics.automationUnits = statsOutput.overallStatistics.automationUnits + eventTypeExecution.Count
-
-					if statsOutput.overallStatistics.keptnServiceExecutions[execution.Name] == nil {
-						statsOutput.overallStatistics.keptnServiceExecutions[execution.Name] = &keptnServiceExecution{
-							eventTypeCount: map[string]int{},
-						}
-					}
-
-					statsOutput.overallStatistics.keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] =
-						statsOutput.overallStatistics.keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] + eventTypeExecution.Count
-
+					addTaskResults(&statsOutput.overallStatistics, execution.Name, taskResults)
 					if isProjectGranularity() {
-						statsOutput.perProjectStatistics[project.Name].automationUnits = statsOutput.perProjectStatistics[project.Name].automationUnits + eventTypeExecution.Count
-						if statsOutput.perProjectStatistics[project.Name].keptnServiceExecutions[execution.Name] == nil {
-							statsOutput.perProjectStatistics[project.Name].keptnServiceExecutions[execution.Name] = &keptnServiceExecution{
-								eventTypeCount: map[string]int{},
-							}
-						}
-						statsOutput.perProjectStatistics[project.Name].keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] =
-							statsOutput.perProjectStatistics[project.Name].keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] + eventTypeExecution.Count
-
+						addTaskResults(statsOutput.perProjectStatistics[project.Name], execution.Name, taskResults)
 						if isServiceGranularity() {
-							statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].automationUnits =
-								statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].automationUnits + eventTypeExecution.Count
-
-							if statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].keptnServiceExecutions[execution.Name] == nil {
-								statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].keptnServiceExecutions[execution.Name] = &keptnServiceExecution{
-									eventTypeCount: map[string]int{},
-								}
-							}
-
-							statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] =
-								statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name].keptnServiceExecutions[execution.Name].eventTypeCount[eventTypeExecution.Type] + eventTypeExecution.Count
+							addTaskResults(statsOutput.perProjectStatistics[project.Name].subStatistics[svc.Name], execution.Name, taskResults)
 						}
 					}
 				}
@@ -748,6 +865,78 @@ func mergeStatisticsResponseIntoStatisticsOutput(stats *stats.GetStatisticsRespo
 	}
 }
 
+// addSummaryGroup adds the automation units and the Keptn service executions of a summary to the statistics
+func addSummaryGroup(s *statistics, group *stats.SummaryGroup) {
+	if group == nil {
+		return
+	}
+	s.automationUnits = s.automationUnits + group.AutomationUnits
+	for keptnServiceName, executions := range group.KeptnServiceExecutions {
+		if s.keptnServiceExecutions[keptnServiceName] == nil {
+			s.keptnServiceExecutions[keptnServiceName] = &keptnServiceExecution{
+				eventTypeCount: map[string]int{},
+			}
+		}
+		for eventType, count := range executions {
+			s.keptnServiceExecutions[keptnServiceName].eventTypeCount[eventType] = s.keptnServiceExecutions[keptnServiceName].eventTypeCount[eventType] + count
+		}
+	}
+}
+
+func addTaskResults(s *statistics, keptnServiceName string, taskResults stats.GetStatisticsResponseTaskResults) {
+	if s.keptnServiceExecutions[keptnServiceName] == nil {
+		s.keptnServiceExecutions[keptnServiceName] = &keptnServiceExecution{
+			eventTypeCount: map[string]int{},
+		}
+	}
+	execution := s.keptnServiceExecutions[keptnServiceName]
+	if execution.resultCount == nil {
+		execution.resultCount = map[string]map[string]int{}
+	}
+	if execution.statusCount == nil {
+		execution.statusCount = map[string]map[string]int{}
+	}
+	if execution.resultCount[taskResults.Type] == nil {
+		execution.resultCount[taskResults.Type] = map[string]int{}
+	}
+	if execution.statusCount[taskResults.Type] == nil {
+		execution.statusCount[taskResults.Type] = map[string]int{}
+	}
+	for _, result := range taskResults.Results {
+		execution.resultCount[taskResults.Type][result.Type] = execution.resultCount[taskResults.Type][result.Type] + result.Count
+	}
+	for _, status := range taskResults.Statuses {
+		execution.statusCount[taskResults.Type][status.Type] = execution.statusCount[taskResults.Type][status.Type] + status.Count
+	}
+}
+
+func addApprovals(s *statistics, approvals *stats.GetStatisticsResponseApprovals) {
+	if s.approvals == nil {
+		s.approvals = &approvalStatistics{}
+	}
+	s.approvals.requested = s.approvals.requested + approvals.Requested
+	s.approvals.approved = s.approvals.approved + approvals.Approved
+	s.approvals.rejected = s.approvals.rejected + approvals.Rejected
+	s.approvals.automatic = s.approvals.automatic + approvals.Automatic
+	s.approvals.manual = s.approvals.manual + approvals.Manual
+	if approvals.TimeToApprove != nil {
+		s.approvals.timeToApproveCount = s.approvals.timeToApproveCount + approvals.TimeToApprove.Count
+		s.approvals.timeToApproveSum = s.approvals.timeToApproveSum + approvals.TimeToApprove.Sum
+	}
+}
+
+func addTrigger(s *statistics, trigger stats.GetStatisticsResponseTrigger) {
+	key := trigger.Type + "/" + trigger.Source
+	if s.triggersByType[key] == nil {
+		s.triggersByType[key] = &triggerExecution{
+			name:      trigger.Source,
+			eventType: trigger.Type,
+		}
+	}
+	s.triggersByType[key].count = s.triggersByType[key].count + trigger.Count
+	s.triggers = s.triggers + trigger.Count
+}
+
 func isProjectGranularity() bool {
 	return granularity == "project" || granularity == "service"
 }
@@ -881,7 +1070,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&includeEvents, "includeEvents", "", "all", "List of events that define an automation unit, default is 'all'")
 	rootCmd.PersistentFlags().StringVarP(&includeServices, "includeServices", "", "all", "List of Services that define an automation unit, default is 'all'")
 	rootCmd.PersistentFlags().StringVarP(&excludeProjects, "excludeProjects", "", "", "List of project names that are excluded from the Summary")
-	rootCmd.PersistentFlags().StringVarP(&includeTriggers, "includeTriggers", "", "all", "List of sequence triggers: [configuration-change, problem.open, evaluation-started] - supported with Keptn >0.8")
+	rootCmd.PersistentFlags().StringVarP(&includeTriggers, "includeTriggers", "", "all", "List of sequence triggers: [configuration-change, delivery, evaluation, problem, remediation]")
 	rootCmd.PersistentFlags().StringVarP(&export, "export", "", "json", "The format to export the statistics, supported are [json, csv]")
 	rootCmd.PersistentFlags().StringVarP(&separator, "separator", "", ",", "The separator used for the CSV exporter, allowed values are ',' or ';'")
 	rootCmd.PersistentFlags().StringVarP(&outputFile, "output", "o", "stats", "The name of the output file")
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+	"time"
+)
+
+// GetCompare godoc
+// @Summary Compare statistics of two time frames
+// @Description get the counts of two time frames and their delta for every project, service, Keptn service and event type
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   baselineFrom     query    string     false        "Start of the baseline time frame (default: time frame of the same length before 'from')"
+// @Param   baselineTo     query    string     false        "End of the baseline time frame"
+// @Param   baselinePeriod     query    string     false        "Calendar period of the baseline time frame, e.g. lastMonth"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   stage     query    string     false        "Only include statistics of the given stage"
+// @Param   project     query    string     false        "Comma-separated list of projects, may contain '*' as a wildcard"
+// @Param   service     query    string     false        "Comma-separated list of services, may contain '*' as a wildcard"
+// @Param   keptnService     query    string     false        "Comma-separated list of Keptn services, may contain '*' as a wildcard"
+// @Param   eventType     query    string     false        "Comma-separated list of event types or task names, may contain '*' as a wildcard"
+// @Param   sort     query    string     false        "Order of all lists: name or count (absolute delta), optionally followed by :asc or :desc (default: name:asc)"
+// @Success 200 {object} operations.GetCompareResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /statistics/compare [get]
+func GetCompare(c *gin.Context) {
+	params := &operations.GetCompareParams{}
+	sortOrder, ok := bindStatisticsParams(c, params, &params.GetStatisticsParams)
+	if !ok {
+		return
+	}
+
+	if params.From.IsZero() {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid time frame: 'from' or 'period' is required",
+		})
+		return
+	}
+
+	baselineParams, err := params.GetBaselineParams(time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid baseline time frame: " + err.Error(),
+		})
+		return
+	}
+
+	if baselineParams.From.IsZero() || !validateQueryTimestamps(&baselineParams) {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid baseline time frame: 'baselineFrom' or 'baselinePeriod' is required, and 'baselineFrom' timestamp must not be greater than 'baselineTo' timestamp",
+		})
+		return
+	}
+
+	payload, err := getCompare(&baselineParams, &params.GetStatisticsParams, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return
+	}
+	payload.Sort(sortOrder)
+	payload.Baseline = operations.GetCompareResponseTimeFrame{From: baselineParams.From, To: baselineParams.To}
+	payload.Current = operations.GetCompareResponseTimeFrame{From: params.From, To: params.To}
+
+	c.JSON(http.StatusOK, payload)
+}
+
+func getCompare(baselineParams, currentParams *operations.GetStatisticsParams, sb controller.StatisticsInterface) (operations.GetCompareResponse, error) {
+	baseline, err := getComparedStatistics(baselineParams, sb)
+	if err != nil {
+		return operations.GetCompareResponse{}, err
+	}
+	current, err := getComparedStatistics(currentParams, sb)
+	if err != nil {
+		return operations.GetCompareResponse{}, err
+	}
+	return operations.CompareStatistics(baseline, current), nil
+}
+
+// getComparedStatistics returns the filtered statistics of a time frame. Time frames without statistics are compared as empty statistics
+func getComparedStatistics(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) (operations.Statistics, error) {
+	statistics, err := getMergedStatistics(params, sb)
+	if err == db.NoStatisticsFoundError {
+		return operations.Statistics{}, nil
+	} else if err != nil {
+		return operations.Statistics{}, err
+	}
+	return statistics.Filter(operations.NewStatisticsFilter(*params)), nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+	"time"
+)
+
+// GetDora godoc
+// @Summary Get DORA metrics
+// @Description get the deployment frequency, lead time for changes, change failure rate and time to restore per project, stage and service
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   stage     query    string     false        "Only include metrics of the given stage"
+// @Param   sort     query    string     false        "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)"
+// @Success 200 {object} operations.GetDoraResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /dora [get]
+func GetDora(c *gin.Context) {
+	params := &operations.GetStatisticsParams{}
+	mergedStatistics, sortOrder, ok := getRequestedStatistics(c, params)
+	if !ok {
+		return
+	}
+
+	payload := convertToGetDoraResponse(mergedStatistics, params.From, params.To)
+	payload.Sort(sortOrder)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// convertToGetDoraResponse computes the DORA metrics. The deployment frequency refers to the given time frame
+func convertToGetDoraResponse(mergedStatistics operations.Statistics, from, to time.Time) operations.GetDoraResponse {
+	result := operations.GetDoraResponse{
+		From:     mergedStatistics.From,
+		To:       mergedStatistics.To,
+		Projects: []operations.GetDoraResponseProject{},
+	}
+	overallDora := &operations.DoraStatistics{}
+
+	for projectName, project := range mergedStatistics.Projects {
+		projectDora := &operations.DoraStatistics{}
+		for _, service := range project.Services {
+			projectDora.Merge(service.Dora)
+		}
+
+		newProject := operations.GetDoraResponseProject{
+			Name:     projectName,
+			Dora:     projectDora.ToResponse(from, to),
+			Services: convertToGetDoraResponseServices(project.Services, from, to),
+			Stages:   []operations.GetDoraResponseStage{},
+		}
+
+		for stageName, stage := range project.Stages {
+			stageDora := &operations.DoraStatistics{}
+			for _, service := range stage.Services {
+				stageDora.Merge(service.Dora)
+			}
+			newProject.Stages = append(newProject.Stages, operations.GetDoraResponseStage{
+				Name:     stageName,
+				Dora:     stageDora.ToResponse(from, to),
+				Services: convertToGetDoraResponseServices(stage.Services, from, to),
+			})
+		}
+
+		overallDora.Merge(projectDora)
+		result.Projects = append(result.Projects, newProject)
+	}
+	result.Dora = overallDora.ToResponse(from, to)
+
+	return result
+}
+
+func convertToGetDoraResponseServices(services map[string]*operations.Service, from, to time.Time) []operations.GetDoraResponseService {
+	result := []operations.GetDoraResponseService{}
+	for serviceName, service := range services {
+		result = append(result, operations.GetDoraResponseService{
+			Name: serviceName,
+			Dora: service.Dora.ToResponse(from, to),
+		})
+	}
+	return result
+}
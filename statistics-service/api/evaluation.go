@@ -0,0 +1,100 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+)
+
+// GetEvaluations godoc
+// @Summary Get evaluation statistics
+// @Description get statistics about the quality gate evaluations per project, stage and service
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   stage     query    string     false        "Only include evaluations of the given stage"
+// @Param   sort     query    string     false        "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)"
+// @Success 200 {object} operations.GetEvaluationsResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /evaluations [get]
+func GetEvaluations(c *gin.Context) {
+	params := &operations.GetStatisticsParams{}
+	mergedStatistics, sortOrder, ok := getRequestedStatistics(c, params)
+	if !ok {
+		return
+	}
+
+	payload := convertToGetEvaluationsResponse(mergedStatistics)
+	payload.Sort(sortOrder)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
+
+func convertToGetEvaluationsResponse(mergedStatistics operations.Statistics) operations.GetEvaluationsResponse {
+	result := operations.GetEvaluationsResponse{
+		From:     mergedStatistics.From,
+		To:       mergedStatistics.To,
+		Projects: []operations.GetEvaluationsResponseProject{},
+	}
+	overallEvaluations := operations.NewEvaluationStatistics()
+
+	for projectName, project := range mergedStatistics.Projects {
+		projectEvaluations := operations.NewEvaluationStatistics()
+		for _, service := range project.Services {
+			projectEvaluations.Merge(service.Evaluations)
+		}
+		if projectEvaluations.Count == 0 {
+			continue
+		}
+
+		newProject := operations.GetEvaluationsResponseProject{
+			Name:        projectName,
+			Evaluations: projectEvaluations.ToResponse(),
+			Services:    convertToGetEvaluationsResponseServices(project.Services),
+			Stages:      []operations.GetEvaluationsResponseStage{},
+		}
+
+		for stageName, stage := range project.Stages {
+			stageEvaluations := operations.NewEvaluationStatistics()
+			for _, service := range stage.Services {
+				stageEvaluations.Merge(service.Evaluations)
+			}
+			if stageEvaluations.Count == 0 {
+				continue
+			}
+			newProject.Stages = append(newProject.Stages, operations.GetEvaluationsResponseStage{
+				Name:        stageName,
+				Evaluations: stageEvaluations.ToResponse(),
+				Services:    convertToGetEvaluationsResponseServices(stage.Services),
+			})
+		}
+
+		overallEvaluations.Merge(projectEvaluations)
+		result.Projects = append(result.Projects, newProject)
+	}
+	result.Evaluations = overallEvaluations.ToResponse()
+
+	return result
+}
+
+func convertToGetEvaluationsResponseServices(services map[string]*operations.Service) []operations.GetEvaluationsResponseService {
+	result := []operations.GetEvaluationsResponseService{}
+	for serviceName, service := range services {
+		if service.Evaluations == nil || service.Evaluations.Count == 0 {
+			continue
+		}
+		result = append(result, operations.GetEvaluationsResponseService{
+			Name:        serviceName,
+			Evaluations: service.Evaluations.ToResponse(),
+		})
+	}
+	return result
+}
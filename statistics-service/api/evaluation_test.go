@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"testing"
+)
+
+func Test_convertToGetEvaluationsResponse(t *testing.T) {
+	statistics := operations.Statistics{}
+	statistics.AddEvaluation("my-project", "hardening", "carts", "pass", 100, true)
+	statistics.AddEvaluation("my-project", "hardening", "carts", "fail", 20, true)
+	statistics.AddEvaluation("my-project", "production", "carts", "pass", 90, true)
+	statistics.AddEvaluation("my-legacy-project", "", "carts", "warning", 60, true)
+	statistics.IncreaseEventTypeCount("my-project-without-evaluations", "dev", "carts", "my-type", 1)
+
+	got := convertToGetEvaluationsResponse(statistics.WithProjectTotals())
+
+	if got.Evaluations.Count != 4 {
+		t.Errorf("convertToGetEvaluationsResponse(): want %d evaluations, got %d", 4, got.Evaluations.Count)
+	}
+	if len(got.Projects) != 2 {
+		t.Fatalf("convertToGetEvaluationsResponse(): want %d projects, got %d", 2, len(got.Projects))
+	}
+	for _, project := range got.Projects {
+		switch project.Name {
+		case "my-project":
+			if project.Evaluations.Count != 3 || project.Evaluations.Pass != 2 || len(project.Stages) != 2 || len(project.Services) != 1 {
+				t.Errorf("convertToGetEvaluationsResponse(): unexpected evaluations for project my-project: %v", project)
+			}
+			for _, stage := range project.Stages {
+				if stage.Name == "hardening" && (stage.Evaluations.Count != 2 || stage.Evaluations.FailRatio != 0.5 || stage.Evaluations.AverageScore != 60) {
+					t.Errorf("convertToGetEvaluationsResponse(): unexpected evaluations for stage hardening: %v", stage.Evaluations)
+				}
+			}
+		case "my-legacy-project":
+			if project.Evaluations.Count != 1 || len(project.Stages) != 0 || len(project.Services) != 1 {
+				t.Errorf("convertToGetEvaluationsResponse(): unexpected evaluations for project my-legacy-project: %v", project)
+			}
+		default:
+			t.Errorf("convertToGetEvaluationsResponse(): unexpected project %s", project.Name)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	"net/http"
 )
@@ -20,12 +21,15 @@ import (
 // @Failure 500 {object} operations.Error "Internal error"
 // @Router /event [post]
 func HandleEvent(c *gin.Context) {
+	metrics.EventsReceived.Inc()
 	event := &operations.Event{}
 	if err := c.ShouldBindJSON(event); err != nil {
+		metrics.EventsRejected.WithLabelValues(metrics.RejectionInvalidPayload).Inc()
 		c.JSON(http.StatusBadRequest, operations.Error{
 			ErrorCode: 400,
 			Message:   "Invalid request format",
 		})
+		return
 	}
 
 	sb := controller.GetStatisticsBucketInstance()
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"net/http"
+	"time"
+)
+
+// GetHealth is used as the liveness probe. It responds with 503 if the buckets are not stored periodically anymore
+func GetHealth(c *gin.Context) {
+	sb := controller.GetStatisticsBucketInstance()
+	payload := sb.GetLiveness(time.Now())
+	if !payload.IsHealthy() {
+		c.JSON(http.StatusServiceUnavailable, payload)
+		return
+	}
+	c.JSON(http.StatusOK, payload)
+}
+
+// GetReady is used as the readiness probe. It responds with 503 if MongoDB can not be reached. A failed flush is only reported,
+// since the bucket is kept and stored with the next flush
+func GetReady(c *gin.Context) {
+	sb := controller.GetStatisticsBucketInstance()
+	payload := sb.GetReadiness()
+	if !payload.IsHealthy() {
+		c.JSON(http.StatusServiceUnavailable, payload)
+		return
+	}
+	c.JSON(http.StatusOK, payload)
+}
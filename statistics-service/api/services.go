@@ -0,0 +1,65 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+)
+
+// GetKeptnServices godoc
+// @Summary Get Keptn service inventory
+// @Description get the Keptn services and their versions that have sent events per project
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   sort     query    string     false        "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)"
+// @Success 200 {object} operations.GetKeptnServicesResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /services [get]
+func GetKeptnServices(c *gin.Context) {
+	params := &operations.GetStatisticsParams{}
+	mergedStatistics, sortOrder, ok := getRequestedStatistics(c, params)
+	if !ok {
+		return
+	}
+
+	payload := convertToGetKeptnServicesResponse(mergedStatistics)
+	payload.Sort(sortOrder)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
+
+func convertToGetKeptnServicesResponse(mergedStatistics operations.Statistics) operations.GetKeptnServicesResponse {
+	result := operations.GetKeptnServicesResponse{
+		From:     mergedStatistics.From,
+		To:       mergedStatistics.To,
+		Projects: []operations.GetKeptnServicesResponseProject{},
+	}
+
+	for projectName, project := range mergedStatistics.Projects {
+		newProject := operations.GetKeptnServicesResponseProject{
+			Name:          projectName,
+			KeptnServices: []operations.GetKeptnServicesResponseKeptnService{},
+		}
+		for keptnServiceName, keptnService := range project.KeptnServices {
+			newKeptnService := operations.GetKeptnServicesResponseKeptnService{
+				Name:     keptnServiceName,
+				Versions: []operations.KeptnServiceVersion{},
+			}
+			for _, version := range keptnService.Versions {
+				newKeptnService.Versions = append(newKeptnService.Versions, *version)
+			}
+			newProject.KeptnServices = append(newProject.KeptnServices, newKeptnService)
+		}
+		result.Projects = append(result.Projects, newProject)
+	}
+	return result
+}
@@ -2,12 +2,12 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	keptn "github.com/keptn/go-utils/pkg/lib"
 	"net/http"
+	"time"
 )
 
 // GetStatistics godoc
@@ -17,21 +17,103 @@ import (
 // @Security ApiKeyAuth
 // @Accept  json
 // @Produce  json
-// @Param   from     query    string     false        "From"
-// @Param   to     query    string     false        "To"
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   stage     query    string     false        "Only include statistics of the given stage"
+// @Param   groupBy     query    string     false        "Return the totals per value of a label, e.g. label:team"
+// @Param   project     query    string     false        "Comma-separated list of projects, may contain '*' as a wildcard"
+// @Param   service     query    string     false        "Comma-separated list of services, may contain '*' as a wildcard"
+// @Param   keptnService     query    string     false        "Comma-separated list of Keptn services, may contain '*' as a wildcard"
+// @Param   eventType     query    string     false        "Comma-separated list of event types or task names, may contain '*' as a wildcard"
+// @Param   sort     query    string     false        "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)"
 // @Success 200 {object} operations.Statistics	"ok"
 // @Failure 400 {object} operations.Error "Invalid payload"
 // @Failure 500 {object} operations.Error "Internal error"
 // @Router /statistics [get]
 func GetStatistics(c *gin.Context) {
-	logger := keptn.NewLogger("", "", "statistics-service")
 	params := &operations.GetStatisticsParams{}
-	if err := c.ShouldBindQuery(params); err != nil {
+	sortOrder, ok := bindStatisticsParams(c, params, params)
+	if !ok {
+		return
+	}
+
+	if params.GroupBy != "" {
+		if _, err := operations.ParseGroupBy(params.GroupBy); err != nil {
+			c.JSON(http.StatusBadRequest, operations.Error{
+				ErrorCode: 400,
+				Message:   err.Error(),
+			})
+			return
+		}
+	}
+
+	payload, err := getStatistics(params, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return
+	}
+	payload.Sort(sortOrder)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
+
+func getStatistics(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) (operations.GetStatisticsResponse, error) {
+	mergedStatistics, err := getMergedStatistics(params, sb)
+	if err != nil {
+		return operations.GetStatisticsResponse{}, err
+	}
+	mergedStatistics = mergedStatistics.Filter(operations.NewStatisticsFilter(*params))
+	result, err := convertToGetStatisticsResponse(mergedStatistics)
+	if err != nil {
+		return operations.GetStatisticsResponse{}, err
+	}
+	if params.GroupBy != "" {
+		labelKey, err := operations.ParseGroupBy(params.GroupBy)
+		if err != nil {
+			return operations.GetStatisticsResponse{}, err
+		}
+		result.GroupBy = params.GroupBy
+		result.Groups = mergedStatistics.GroupByLabel(labelKey)
+	}
+	return result, nil
+}
+
+// getRequestedStatistics binds the query parameters and returns the merged statistics of the requested time frame together with the sort order.
+// If a parameter is invalid or the statistics can not be retrieved, the error response is written and false is returned
+func getRequestedStatistics(c *gin.Context, params *operations.GetStatisticsParams) (operations.Statistics, operations.SortOrder, bool) {
+	sortOrder, ok := bindStatisticsParams(c, params, params)
+	if !ok {
+		return operations.Statistics{}, sortOrder, false
+	}
+	mergedStatistics, err := getMergedStatistics(params, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return operations.Statistics{}, sortOrder, false
+	}
+	return mergedStatistics, sortOrder, true
+}
+
+// bindStatisticsParams binds the query parameters, resolves the time frame and parses the sort order. The query may be a type that embeds the statistics parameters.
+// If a parameter is invalid, a 400 response is written and false is returned
+func bindStatisticsParams(c *gin.Context, query interface{}, params *operations.GetStatisticsParams) (operations.SortOrder, bool) {
+	if err := c.ShouldBindQuery(query); err != nil {
 		c.JSON(http.StatusBadRequest, operations.Error{
 			ErrorCode: 400,
 			Message:   "Invalid request format",
 		})
-		return
+		return operations.DefaultSortOrder, false
+	}
+
+	if err := params.ResolveTimeFrame(time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid time frame: " + err.Error(),
+		})
+		return operations.DefaultSortOrder, false
 	}
 
 	if !validateQueryTimestamps(params) {
@@ -39,62 +121,49 @@ func GetStatistics(c *gin.Context) {
 			ErrorCode: 400,
 			Message:   "Invalid time frame: 'from' timestamp must not be greater than 'to' timestamp",
 		})
-		return
+		return operations.DefaultSortOrder, false
 	}
 
-	sb := controller.GetStatisticsBucketInstance()
-
-	payload, err := getStatistics(params, sb)
+	sortOrder, err := operations.ParseSortOrder(params.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   err.Error(),
+		})
+		return operations.DefaultSortOrder, false
+	}
+	return sortOrder, true
+}
 
-	if err != nil && err == db.NoStatisticsFoundError {
+// writeStatisticsError writes the response for an error that occurred while retrieving the statistics
+func writeStatisticsError(c *gin.Context, err error) {
+	if err == db.NoStatisticsFoundError {
 		c.JSON(http.StatusNotFound, operations.Error{
 			Message:   "no statistics found for selected time frame",
 			ErrorCode: 404,
 		})
 		return
-	} else if err != nil {
-		logger.Error("could not retrieve statistics: " + err.Error())
-		c.JSON(http.StatusInternalServerError, operations.Error{
-			Message:   "Internal server error",
-			ErrorCode: 500,
-		})
-		return
 	}
-	payload.From = params.From
-	payload.To = params.To
-
-	c.JSON(http.StatusOK, payload)
+	keptn.NewLogger("", "", "statistics-service").Error("could not retrieve statistics: " + err.Error())
+	c.JSON(http.StatusInternalServerError, operations.Error{
+		Message:   "Internal server error",
+		ErrorCode: 500,
+	})
 }
 
-func getStatistics(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) (operations.GetStatisticsResponse, error) {
+// getMergedStatistics merges the in-memory bucket and the buckets stored in the database that lie within the requested time frame
+func getMergedStatistics(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) (operations.Statistics, error) {
 	var mergedStatistics = operations.Statistics{}
 
-	cutoffTime := sb.GetCutoffTime()
-
-	// check time
-	if params.From.After(cutoffTime) {
+	if params.From.After(sb.GetCutoffTime()) {
 		// case 1: time frame within "in-memory" interval (e.g. last 30 minutes)
 		// -> return in-memory object
-		mergedStatistics = sb.GetStatistics()
+		mergedStatistics = sb.GetStatistics().WithProjectTotals()
 
 	} else {
-		var statistics []operations.Statistics
-		var err error
-		if params.From.Before(cutoffTime) && params.To.Before(cutoffTime) {
-			// case 2: time frame outside of "in-memory" interval
-			// -> return results from database
-			statistics, err = sb.GetRepo().GetStatistics(params.From, params.To)
-			if err != nil && err == db.NoStatisticsFoundError {
-				return operations.GetStatisticsResponse{}, err
-			}
-		} else if params.From.Before(cutoffTime) && params.To.After(cutoffTime) {
-			// case 3: time frame includes "in-memory" interval
-			// -> get results from database and from in-memory and merge them
-			statistics, err = sb.GetRepo().GetStatistics(params.From, params.To)
-			if statistics == nil {
-				statistics = []operations.Statistics{}
-			}
-			statistics = append(statistics, sb.GetStatistics())
+		statistics, err := getStatisticsBuckets(params, sb)
+		if err != nil {
+			return operations.Statistics{}, err
 		}
 
 		mergedStatistics = operations.Statistics{
@@ -103,69 +172,185 @@ func getStatistics(params *operations.GetStatisticsParams, sb controller.Statist
 		}
 		mergedStatistics = operations.MergeStatistics(mergedStatistics, statistics)
 	}
-	return convertToGetStatisticsResponse(mergedStatistics)
+
+	if params.Stage != "" {
+		// statistics that have been stored before stages have been tracked do not contain any stage information and are therefore omitted
+		mergedStatistics = mergedStatistics.FilterStage(params.Stage)
+	}
+	return mergedStatistics, nil
 }
 
 func convertToGetStatisticsResponse(mergedStatistics operations.Statistics) (operations.GetStatisticsResponse, error) {
 	result := operations.GetStatisticsResponse{
-		From:     mergedStatistics.From,
-		To:       mergedStatistics.To,
-		Projects: []operations.GetStatisticsResponseProject{},
+		From:           mergedStatistics.From,
+		To:             mergedStatistics.To,
+		Projects:       []operations.GetStatisticsResponseProject{},
+		Overflows:      mergedStatistics.Overflows,
+		FilteredEvents: mergedStatistics.FilteredEvents,
 	}
 
+	// distinct Keptn contexts of projects and of the whole time frame are determined by merging the sketches of the services
+	overallUniqueSequences := operations.NewHyperLogLog()
+
 	for projectName, project := range mergedStatistics.Projects {
 		newProject := operations.GetStatisticsResponseProject{
 			Name:     projectName,
 			Services: []operations.GetStatisticsResponseService{},
 		}
+		projectUniqueSequences := operations.NewHyperLogLog()
+		projectApprovals := &operations.ApprovalStatistics{}
+		projectTriggers := map[string]map[string]int{}
 
 		for serviceName, service := range project.Services {
-			newService := operations.GetStatisticsResponseService{
-				Name:                   serviceName,
-				Events:                 []operations.GetStatisticsResponseEvent{},
-				KeptnServiceExecutions: []operations.GetStatisticsResponseKeptnService{},
-			}
+			newProject.Services = append(newProject.Services, convertToGetStatisticsResponseService(serviceName, service))
+			projectUniqueSequences.Merge(service.UniqueSequences)
+			projectApprovals.Merge(service.Approvals)
+			projectTriggers = operations.MergeTriggers(projectTriggers, service.Triggers)
+		}
+		newProject.Approvals = projectApprovals.ToResponse()
+		newProject.Triggers = operations.ConvertToGetStatisticsResponseTriggers(projectTriggers)
 
-			for eventType, eventTypeCount := range service.Events {
-				newService.Events = append(newService.Events, operations.GetStatisticsResponseEvent{
-					Type:  eventType,
-					Count: eventTypeCount,
-				})
+		for stageName, stage := range project.Stages {
+			newStage := operations.GetStatisticsResponseStage{
+				Name:     stageName,
+				Services: []operations.GetStatisticsResponseService{},
 			}
+			stageUniqueSequences := operations.NewHyperLogLog()
+			stageApprovals := &operations.ApprovalStatistics{}
+			stageSequences := map[string]int{}
 
-			env := config.GetConfig()
-			if env.NextGenEvents {
-				newService.ExecutedSequencesPerType = []operations.GetStatisticsResponseEvent{}
-				for eventType, count := range service.ExecutedSequencesPerType {
-					newService.ExecutedSequencesPerType = append(newService.ExecutedSequencesPerType, operations.GetStatisticsResponseEvent{
-						Type:  eventType,
-						Count: count,
-					})
+			for serviceName, service := range stage.Services {
+				newStage.Services = append(newStage.Services, convertToGetStatisticsResponseService(serviceName, service))
+				stageUniqueSequences.Merge(service.UniqueSequences)
+				stageApprovals.Merge(service.Approvals)
+				for sequence, count := range service.ExecutedSequencesPerType {
+					stageSequences[sequence] = stageSequences[sequence] + count
 				}
 			}
-
-			for keptnServiceName, keptnService := range service.KeptnServiceExecutions {
-				newKeptnService := operations.GetStatisticsResponseKeptnService{
-					Name:       keptnServiceName,
-					Executions: []operations.GetStatisticsResponseEvent{},
-				}
-
-				for eventType, eventTypeCount := range keptnService.Executions {
-					newKeptnService.Executions = append(newKeptnService.Executions, operations.GetStatisticsResponseEvent{
-						Type:  eventType,
-						Count: eventTypeCount,
-					})
-				}
-				newService.KeptnServiceExecutions = append(newService.KeptnServiceExecutions, newKeptnService)
+			newStage.Approvals = stageApprovals.ToResponse()
+			for sequence, count := range stageSequences {
+				newStage.ExecutedSequences = append(newStage.ExecutedSequences, operations.GetStatisticsResponseEvent{
+					Type:  sequence,
+					Count: count,
+				})
 			}
-			newProject.Services = append(newProject.Services, newService)
+			newStage.UniqueSequences = stageUniqueSequences.Count()
+			newProject.Stages = append(newProject.Stages, newStage)
 		}
+
+		newProject.UniqueSequences = projectUniqueSequences.Count()
+		overallUniqueSequences.Merge(projectUniqueSequences)
 		result.Projects = append(result.Projects, newProject)
 	}
+	result.UniqueSequences = overallUniqueSequences.Count()
 
 	return result, nil
 }
 
+func convertToGetStatisticsResponseService(serviceName string, service *operations.Service) operations.GetStatisticsResponseService {
+	newService := operations.GetStatisticsResponseService{
+		Name:                   serviceName,
+		UniqueSequences:        service.UniqueSequences.Count(),
+		Evaluations:            service.Evaluations.ToResponse(),
+		Remediation:            service.Remediation.ToResponse(),
+		Approvals:              service.Approvals.ToResponse(),
+		Triggers:               operations.ConvertToGetStatisticsResponseTriggers(service.Triggers),
+		Events:                 []operations.GetStatisticsResponseEvent{},
+		KeptnServiceExecutions: []operations.GetStatisticsResponseKeptnService{},
+	}
+
+	for eventType, eventTypeCount := range service.Events {
+		newService.Events = append(newService.Events, operations.GetStatisticsResponseEvent{
+			Type:  eventType,
+			Count: eventTypeCount,
+		})
+	}
+
+	if len(service.ExecutedSequencesPerType) > 0 {
+		newService.ExecutedSequencesPerType = []operations.GetStatisticsResponseEvent{}
+		for eventType, count := range service.ExecutedSequencesPerType {
+			newService.ExecutedSequencesPerType = append(newService.ExecutedSequencesPerType, operations.GetStatisticsResponseEvent{
+				Type:  eventType,
+				Count: count,
+			})
+		}
+	}
+
+	for keptnServiceName, keptnService := range service.KeptnServiceExecutions {
+		newKeptnService := operations.GetStatisticsResponseKeptnService{
+			Name:       keptnServiceName,
+			Executions: []operations.GetStatisticsResponseEvent{},
+		}
+
+		for eventType, eventTypeCount := range keptnService.Executions {
+			newKeptnService.Executions = append(newKeptnService.Executions, operations.GetStatisticsResponseEvent{
+				Type:  eventType,
+				Count: eventTypeCount,
+			})
+		}
+		for taskType, duration := range keptnService.Durations {
+			newKeptnService.Durations = append(newKeptnService.Durations, duration.ToResponse(taskType))
+		}
+		for taskType, taskResults := range keptnService.TaskResults {
+			newKeptnService.TaskResults = append(newKeptnService.TaskResults, convertToGetStatisticsResponseTaskResults(taskType, taskResults))
+		}
+		newService.KeptnServiceExecutions = append(newService.KeptnServiceExecutions, newKeptnService)
+	}
+
+	for sequenceType, duration := range service.SequenceDurations {
+		newService.SequenceDurations = append(newService.SequenceDurations, duration.ToResponse(sequenceType))
+	}
+	return newService
+}
+
+func convertToGetStatisticsResponseTaskResults(taskType string, taskResults *operations.TaskResults) operations.GetStatisticsResponseTaskResults {
+	result := operations.GetStatisticsResponseTaskResults{
+		Type:     taskType,
+		Results:  []operations.GetStatisticsResponseEvent{},
+		Statuses: []operations.GetStatisticsResponseEvent{},
+	}
+	for taskResult, count := range taskResults.Results {
+		result.Results = append(result.Results, operations.GetStatisticsResponseEvent{
+			Type:  taskResult,
+			Count: count,
+		})
+	}
+	for status, count := range taskResults.Statuses {
+		result.Statuses = append(result.Statuses, operations.GetStatisticsResponseEvent{
+			Type:  status,
+			Count: count,
+		})
+	}
+	return result
+}
+
+// getStatisticsBuckets returns the in-memory bucket and the buckets stored in the database that lie within the requested time frame
+func getStatisticsBuckets(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) ([]operations.Statistics, error) {
+	cutoffTime := sb.GetCutoffTime()
+
+	var statistics []operations.Statistics
+	var err error
+	if params.From.After(cutoffTime) {
+		statistics = []operations.Statistics{sb.GetStatistics()}
+	} else if params.From.Before(cutoffTime) && params.To.Before(cutoffTime) {
+		// case 2: time frame outside of "in-memory" interval
+		// -> return results from database
+		statistics, err = sb.GetRepo().GetStatistics(params.From, params.To)
+		if err != nil && err == db.NoStatisticsFoundError {
+			return nil, err
+		}
+	} else if params.From.Before(cutoffTime) && params.To.After(cutoffTime) {
+		// case 3: time frame includes "in-memory" interval
+		// -> get results from database and from in-memory and merge them
+		statistics, err = sb.GetRepo().GetStatistics(params.From, params.To)
+		if statistics == nil {
+			statistics = []operations.Statistics{}
+		}
+		statistics = append(statistics, sb.GetStatistics())
+	}
+	return statistics, nil
+}
+
 func validateQueryTimestamps(params *operations.GetStatisticsParams) bool {
 	if params.To.Before(params.From) {
 		return false
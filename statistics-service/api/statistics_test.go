@@ -1,10 +1,14 @@
 package api
 
 import (
+	"errors"
+	"github.com/gin-gonic/gin"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -78,6 +82,8 @@ type MockStatisticsRepo struct {
 	StoreStatisticsFunc func(statistics operations.Statistics) error
 	// DeleteStatisticsFunc godoc
 	DeleteStatisticsFunc func(from, to time.Time) error
+	// CheckConnectionFunc godoc
+	CheckConnectionFunc func() error
 }
 
 // GetStatistics godoc
@@ -95,6 +101,11 @@ func (m *MockStatisticsRepo) DeleteStatistics(from, to time.Time) error {
 	return m.DeleteStatisticsFunc(from, to)
 }
 
+// CheckConnection godoc
+func (m *MockStatisticsRepo) CheckConnection() error {
+	return m.CheckConnectionFunc()
+}
+
 func Test_getStatistics(t *testing.T) {
 	type args struct {
 		params     *operations.GetStatisticsParams
@@ -140,6 +151,110 @@ func Test_getStatistics(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "get in-memory bucket for stage",
+			args: args{
+				params: &operations.GetStatisticsParams{
+					From:  time.Now(),
+					To:    time.Now().Add(5 * time.Minute),
+					Stage: "dev",
+				},
+				statistics: &MockStatisticsInterface{
+					CutoffTime: time.Now().Add(-1 * time.Minute),
+					Statistics: &operations.Statistics{
+						Projects: map[string]*operations.Project{
+							"my-project": {
+								Name: "my-project",
+								Services: map[string]*operations.Service{
+									"my-service": {
+										Name: "my-service",
+										Events: map[string]int{
+											"my-type": 3,
+										},
+									},
+								},
+								Stages: map[string]*operations.Stage{
+									"dev": {
+										Name: "dev",
+										Services: map[string]*operations.Service{
+											"my-service": {
+												Name: "my-service",
+												Events: map[string]int{
+													"my-type": 1,
+												},
+												ExecutedSequencesPerType: map[string]int{
+													"delivery": 2,
+												},
+											},
+										},
+									},
+								},
+							},
+							"my-project-without-stages": {
+								Name:     "my-project-without-stages",
+								Services: map[string]*operations.Service{},
+							},
+						},
+					},
+					Repo: nil,
+				},
+			},
+			want: operations.GetStatisticsResponse{
+				Projects: []operations.GetStatisticsResponseProject{
+					{
+						Name: "my-project",
+						Services: []operations.GetStatisticsResponseService{
+							{
+								Name: "my-service",
+								Events: []operations.GetStatisticsResponseEvent{
+									{
+										Type:  "my-type",
+										Count: 1,
+									},
+								},
+								KeptnServiceExecutions: []operations.GetStatisticsResponseKeptnService{},
+								ExecutedSequencesPerType: []operations.GetStatisticsResponseEvent{
+									{
+										Type:  "delivery",
+										Count: 2,
+									},
+								},
+							},
+						},
+						Stages: []operations.GetStatisticsResponseStage{
+							{
+								Name: "dev",
+								Services: []operations.GetStatisticsResponseService{
+									{
+										Name: "my-service",
+										Events: []operations.GetStatisticsResponseEvent{
+											{
+												Type:  "my-type",
+												Count: 1,
+											},
+										},
+										KeptnServiceExecutions: []operations.GetStatisticsResponseKeptnService{},
+										ExecutedSequencesPerType: []operations.GetStatisticsResponseEvent{
+											{
+												Type:  "delivery",
+												Count: 2,
+											},
+										},
+									},
+								},
+								ExecutedSequences: []operations.GetStatisticsResponseEvent{
+									{
+										Type:  "delivery",
+										Count: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "get bucket from db",
 			args: args{
@@ -276,3 +391,64 @@ func Test_getStatistics(t *testing.T) {
 		})
 	}
 }
+
+func Test_bindStatisticsParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantOK     bool
+		wantStatus int
+		wantSort   operations.SortOrder
+	}{
+		{
+			name:     "valid time frame and sort order",
+			query:    "from=2020-10-01T00:00:00Z&to=2020-10-02T00:00:00Z&sort=count:desc",
+			wantOK:   true,
+			wantSort: operations.SortOrder{Field: operations.SortByCount, Descending: true},
+		},
+		{
+			name:       "invalid time frame",
+			query:      "from=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "from after to",
+			query:      "from=2020-10-02T00:00:00Z&to=2020-10-01T00:00:00Z",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid sort order",
+			query:      "from=2020-10-01T00:00:00Z&sort=size",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/v1/statistics?"+tt.query, nil)
+
+			params := &operations.GetTopParams{}
+			sortOrder, ok := bindStatisticsParams(c, params, &params.GetStatisticsParams)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantSort, sortOrder)
+				assert.False(t, params.From.IsZero())
+			} else {
+				assert.Equal(t, tt.wantStatus, recorder.Code)
+			}
+		})
+	}
+}
+
+func Test_writeStatisticsError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	writeStatisticsError(c, db.NoStatisticsFoundError)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(recorder)
+	writeStatisticsError(c, errors.New("connection refused"))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+)
+
+// GetSummary godoc
+// @Summary Get automation unit summary
+// @Description get the automation units, i.e. the number of executions of the included Keptn services and event types, overall, per project or per service. The CLI uses the same computation
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   groupBy     query    string     false        "Level of the totals: overall (default), project or service"
+// @Param   includeEvents     query    string     false        "Comma-separated list of event types that define an automation unit (default: all)"
+// @Param   includeServices     query    string     false        "Comma-separated list of Keptn services that define an automation unit (default: all)"
+// @Param   excludeProjects     query    string     false        "Comma-separated list of projects that are not included"
+// @Param   stage     query    string     false        "Only include statistics of the given stage"
+// @Param   sort     query    string     false        "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)"
+// @Success 200 {object} operations.GetSummaryResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /statistics/summary [get]
+func GetSummary(c *gin.Context) {
+	params := &operations.GetSummaryParams{}
+	sortOrder, ok := bindStatisticsParams(c, params, &params.GetStatisticsParams)
+	if !ok {
+		return
+	}
+
+	groupBy := params.GroupBy
+	if groupBy == "" {
+		groupBy = operations.SummaryGroupByOverall
+	}
+	if groupBy != operations.SummaryGroupByOverall && groupBy != operations.SummaryGroupByProject && groupBy != operations.SummaryGroupByService {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid groupBy value: supported values are overall, project and service",
+		})
+		return
+	}
+	// the groupBy parameter of the statistics endpoint groups by labels, which is not supported by summaries
+	params.GroupBy = ""
+
+	statistics, err := getStatistics(&params.GetStatisticsParams, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return
+	}
+
+	summary := operations.NewSummary(operations.NewSummaryOptions(params.IncludeEvents, params.IncludeServices, params.ExcludeProjects))
+	summary.Add(statistics)
+	payload, err := summary.ToResponse(groupBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   err.Error(),
+		})
+		return
+	}
+	payload.Sort(sortOrder)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+)
+
+// GetTimeseries godoc
+// @Summary Get statistics as time series
+// @Description get one data point per step for the selected counters. Steps without statistics have a value of zero
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   step     query    string     true        "Length of a data point: 1h, 1d, 1w or 1M"
+// @Param   counters     query    string     false        "Comma-separated list of counters: events, keptnServiceExecutions, executedSequences, uniqueSequences"
+// @Param   stage     query    string     false        "Only include statistics of the given stage"
+// @Param   project     query    string     false        "Comma-separated list of projects, may contain '*' as a wildcard"
+// @Param   service     query    string     false        "Comma-separated list of services, may contain '*' as a wildcard"
+// @Param   keptnService     query    string     false        "Comma-separated list of Keptn services, may contain '*' as a wildcard"
+// @Param   eventType     query    string     false        "Comma-separated list of event types or task names, may contain '*' as a wildcard"
+// @Success 200 {object} operations.GetTimeseriesResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /statistics/timeseries [get]
+func GetTimeseries(c *gin.Context) {
+	params := &operations.GetTimeseriesParams{}
+	if _, ok := bindStatisticsParams(c, params, &params.GetStatisticsParams); !ok {
+		return
+	}
+
+	if params.From.IsZero() || params.To.IsZero() {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   "Invalid time frame: 'from' or 'period' is required",
+		})
+		return
+	}
+
+	counters, err := operations.ParseTimeseriesCounters(params.Counters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	payload, err := operations.NewTimeseries(params.From, params.To, params.Step, counters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	buckets, err := getTimeseriesBuckets(&params.GetStatisticsParams, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return
+	}
+	payload.AddStatistics(buckets)
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// getTimeseriesBuckets returns the buckets of the time frame with the totals of the projects, filtered by stage and by the filter parameters.
+// Since empty steps are part of a time series, a time frame without statistics is not an error
+func getTimeseriesBuckets(params *operations.GetStatisticsParams, sb controller.StatisticsInterface) ([]operations.Statistics, error) {
+	buckets, err := getStatisticsBuckets(params, sb)
+	if err == db.NoStatisticsFoundError {
+		return []operations.Statistics{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	filter := operations.NewStatisticsFilter(*params)
+	for index := range buckets {
+		buckets[index] = buckets[index].WithProjectTotals()
+		if params.Stage != "" {
+			buckets[index] = buckets[index].FilterStage(params.Stage)
+		}
+		buckets[index] = buckets[index].Filter(filter)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"testing"
+	"time"
+)
+
+func Test_getTimeseriesBuckets(t *testing.T) {
+	now := time.Now()
+	sb := &MockStatisticsInterface{
+		CutoffTime: now.Add(-1 * time.Minute),
+		Statistics: &operations.Statistics{
+			From: now.Add(-1 * time.Minute),
+			Projects: map[string]*operations.Project{
+				"sockshop": {
+					Name: "sockshop",
+					Services: map[string]*operations.Service{
+						"carts":  {Name: "carts", Events: map[string]int{"sh.keptn.event.deployment.finished": 2, "sh.keptn.event.test.finished": 1}},
+						"orders": {Name: "orders", Events: map[string]int{"sh.keptn.event.deployment.finished": 4}},
+					},
+				},
+				"podtato": {
+					Name: "podtato",
+					Services: map[string]*operations.Service{
+						"carts": {Name: "carts", Events: map[string]int{"sh.keptn.event.deployment.finished": 8}},
+					},
+				},
+			},
+		},
+	}
+	params := &operations.GetStatisticsParams{
+		From:      now,
+		To:        now.Add(5 * time.Minute),
+		Project:   "sockshop",
+		Service:   "carts",
+		EventType: "deployment",
+	}
+
+	buckets, err := getTimeseriesBuckets(params, sb)
+	if err != nil {
+		t.Fatalf("getTimeseriesBuckets(): unexpected error: %s", err.Error())
+	}
+	timeseries, _ := operations.NewTimeseries(now.Add(-1*time.Hour), now.Add(time.Hour), "1h", []string{operations.TimeseriesCounterEvents})
+	timeseries.AddStatistics(buckets)
+
+	total := 0
+	for _, dataPoint := range timeseries.DataPoints {
+		total += dataPoint.Values[operations.TimeseriesCounterEvents]
+	}
+	if total != 2 {
+		t.Errorf("getTimeseriesBuckets(): want %d filtered events, got %d", 2, total)
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"net/http"
+)
+
+// GetTop godoc
+// @Summary Get the most active entities
+// @Description get the projects, services, Keptn services or event types with the highest number of executions, events or sequences and their share of the total
+// @Tags Statistics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   by     query    string     true        "Ranked entities: project, service, keptnService or eventType"
+// @Param   metric     query    string     false        "Ranking metric: executions, events or sequences (default: executions)"
+// @Param   n     query    int     false        "Maximum number of entries (default: 10)"
+// @Param   from     query    string     false        "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth"
+// @Param   to     query    string     false        "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)"
+// @Param   period     query    string     false        "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear"
+// @Param   timezone     query    string     false        "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)"
+// @Param   stage     query    string     false        "Only include statistics of the given stage"
+// @Param   project     query    string     false        "Comma-separated list of projects, may contain '*' as a wildcard"
+// @Param   service     query    string     false        "Comma-separated list of services, may contain '*' as a wildcard"
+// @Param   keptnService     query    string     false        "Comma-separated list of Keptn services, may contain '*' as a wildcard"
+// @Param   eventType     query    string     false        "Comma-separated list of event types or task names, may contain '*' as a wildcard"
+// @Success 200 {object} operations.GetTopResponse	"ok"
+// @Failure 400 {object} operations.Error "Invalid payload"
+// @Failure 404 {object} operations.Error "No statistics found"
+// @Failure 500 {object} operations.Error "Internal error"
+// @Router /statistics/top [get]
+func GetTop(c *gin.Context) {
+	params := &operations.GetTopParams{}
+	if _, ok := bindStatisticsParams(c, params, &params.GetStatisticsParams); !ok {
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, operations.Error{
+			ErrorCode: 400,
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	mergedStatistics, err := getMergedStatistics(&params.GetStatisticsParams, controller.GetStatisticsBucketInstance())
+	if err != nil {
+		writeStatisticsError(c, err)
+		return
+	}
+
+	mergedStatistics = mergedStatistics.Filter(operations.NewStatisticsFilter(params.GetStatisticsParams))
+	payload := mergedStatistics.Top(params.By, params.Metric, params.N)
+	payload.From = params.From
+	payload.To = params.To
+
+	c.JSON(http.StatusOK, payload)
+}
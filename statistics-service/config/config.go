@@ -7,8 +7,51 @@ import (
 
 // EnvConfig godoc
 type EnvConfig struct {
-	AggregationIntervalSeconds int  `envconfig:"AGGREGATION_INTERVAL_SECONDS" default:"1800"`
-	NextGenEvents              bool `envconfig:"NEXT_GEN_EVENTS" default:"false"`
+	AggregationIntervalSeconds int `envconfig:"AGGREGATION_INTERVAL_SECONDS" default:"1800"`
+	MaxPendingCorrelations     int `envconfig:"MAX_PENDING_CORRELATIONS" default:"10000"`
+	CorrelationTimeoutSeconds  int `envconfig:"CORRELATION_TIMEOUT_SECONDS" default:"86400"`
+	// MaxTrackedContexts limits the number of Keptn contexts that are remembered to detect the first event of a sequence
+	MaxTrackedContexts int `envconfig:"MAX_TRACKED_CONTEXTS" default:"10000"`
+	// MaxTrackedTasks limits the number of .triggered events of tasks that are remembered to compute task durations
+	MaxTrackedTasks int `envconfig:"MAX_TRACKED_TASKS" default:"10000"`
+	// ClassificationPreset selects the built-in classification rules. If empty, the auto preset is used unless NEXT_GEN_EVENTS is set
+	ClassificationPreset string `envconfig:"CLASSIFICATION_PRESET" default:""`
+	// NextGenEvents is deprecated and selects the next-gen or the legacy preset if CLASSIFICATION_PRESET is not set
+	NextGenEvents *bool `envconfig:"NEXT_GEN_EVENTS"`
+	// ClassificationRulesFile contains the path to a YAML or JSON file with classification rules, which replace the preset
+	ClassificationRulesFile string `envconfig:"CLASSIFICATION_RULES_FILE" default:""`
+	// ClassificationRulesReloadIntervalSeconds godoc
+	ClassificationRulesReloadIntervalSeconds int `envconfig:"CLASSIFICATION_RULES_RELOAD_INTERVAL_SECONDS" default:"30"`
+	// MaxProjects limits the number of distinct projects per bucket; 0 disables the limit
+	MaxProjects int `envconfig:"MAX_PROJECTS" default:"500"`
+	// MaxServices limits the number of distinct services of all projects per bucket; 0 disables the limit
+	MaxServices int `envconfig:"MAX_SERVICES" default:"5000"`
+	// MaxStages limits the number of distinct stages of all projects per bucket; 0 disables the limit
+	MaxStages int `envconfig:"MAX_STAGES" default:"1000"`
+	// MaxKeptnServices limits the number of distinct Keptn services (event sources) per bucket; 0 disables the limit
+	MaxKeptnServices int `envconfig:"MAX_KEPTN_SERVICES" default:"200"`
+	// MaxEventTypes limits the number of distinct event types per bucket; 0 disables the limit
+	MaxEventTypes int `envconfig:"MAX_EVENT_TYPES" default:"500"`
+	// MaxBucketSizeBytes limits the size of a stored bucket, which must stay below the 16MB document limit of MongoDB; 0 disables the limit
+	MaxBucketSizeBytes int `envconfig:"MAX_BUCKET_SIZE_BYTES" default:"15728640"`
+	// IngestionFiltersFile contains the path to a YAML or JSON file with include and exclude patterns, which are added to the patterns below
+	IngestionFiltersFile string   `envconfig:"INGESTION_FILTERS_FILE" default:""`
+	IncludeProjects      []string `envconfig:"INCLUDE_PROJECTS" default:""`
+	ExcludeProjects      []string `envconfig:"EXCLUDE_PROJECTS" default:""`
+	IncludeServices      []string `envconfig:"INCLUDE_SERVICES" default:""`
+	ExcludeServices      []string `envconfig:"EXCLUDE_SERVICES" default:""`
+	IncludeSources       []string `envconfig:"INCLUDE_SOURCES" default:""`
+	ExcludeSources       []string `envconfig:"EXCLUDE_SOURCES" default:""`
+	IncludeEventTypes    []string `envconfig:"INCLUDE_EVENT_TYPES" default:""`
+	ExcludeEventTypes    []string `envconfig:"EXCLUDE_EVENT_TYPES" default:""`
+	// LabelDimensions contains the keys of the labels whose values are counted
+	LabelDimensions []string `envconfig:"LABEL_DIMENSIONS" default:""`
+	// MaxLabelValues limits the number of distinct values per label and bucket; 0 disables the limit
+	MaxLabelValues int `envconfig:"MAX_LABEL_VALUES" default:"100"`
+	// MetricsLabels contains the labels of the usage counters exposed at /metrics, i.e. a subset of project, service, keptn_service and type
+	MetricsLabels []string `envconfig:"METRICS_LABELS" default:"project,service,keptn_service,type"`
+	// MetricsMaxSeries limits the number of series of each usage counter; 0 disables the limit
+	MetricsMaxSeries int `envconfig:"METRICS_MAX_SERIES" default:"10000"`
 }
 
 var env EnvConfig
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"strings"
+	"time"
+)
+
+const gatekeeperService = "gatekeeper-service"
+
+const approvalModeAutomatic = "automatic"
+
+// trackApprovals counts requested, approved and rejected approvals and records the time to approve.
+// Whether an approval has been automatic is determined by the approval strategy of the approval.triggered event for the evaluation result.
+// If the approval.triggered event is not known, approvals finished by the gatekeeper-service are considered as automatic
+func (sb *statisticsBucket) trackApprovals(event operations.Event, now time.Time) {
+	eventTime, err := event.GetTime()
+	if err != nil {
+		eventTime = now
+	}
+
+	switch event.Type {
+	case "sh.keptn.event.approval.triggered":
+		sb.Statistics.IncreaseApprovalRequestCount(event.Data.Project, event.Data.Stage, event.Data.Service, 1)
+		if sb.correlator != nil {
+			sb.correlator.addWithValue(getApprovalCorrelationKey(event, event.ID), eventTime, getApprovalMode(event), now)
+		}
+	case "sh.keptn.event.approval.finished":
+		automatic := event.Source == gatekeeperService
+		timeToApprove := time.Duration(-1)
+		if sb.correlator != nil {
+			if triggeredTime, mode, ok := sb.correlator.resolveWithValue(getApprovalCorrelationKey(event, event.Triggeredid), now); ok {
+				timeToApprove = eventTime.Sub(triggeredTime)
+				if mode != "" {
+					automatic = mode == approvalModeAutomatic
+				}
+			}
+		}
+		result, status := getApprovalDecision(event)
+		approved := !isFailedResult(result, status)
+		sb.Statistics.AddApproval(event.Data.Project, event.Data.Stage, event.Data.Service, approved, automatic, timeToApprove)
+	}
+}
+
+// getApprovalMode returns the approval strategy (automatic or manual) that applies to the evaluation result of the approval.triggered event
+func getApprovalMode(event operations.Event) string {
+	if event.Data.Approval == nil {
+		return ""
+	}
+	if strings.EqualFold(event.Data.Result, "warning") {
+		return strings.ToLower(event.Data.Approval.Warning)
+	}
+	return strings.ToLower(event.Data.Approval.Pass)
+}
+
+// getApprovalDecision returns the result and status of an approval.finished event. Keptn < 0.8 sets them in the approval property of the event data
+func getApprovalDecision(event operations.Event) (string, string) {
+	if event.Data.Result == "" && event.Data.Approval != nil {
+		return event.Data.Approval.Result, event.Data.Approval.Status
+	}
+	return event.Data.Result, event.Data.Status
+}
+
+func getApprovalCorrelationKey(event operations.Event, triggeredID string) string {
+	if triggeredID != "" {
+		return "approval/" + triggeredID
+	}
+	return "approval/" + event.Shkeptncontext + "/" + event.Data.Stage
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+	"time"
+)
+
+func Test_statisticsBucket_trackApprovals(t *testing.T) {
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	newEvent := func(eventType, source, id, triggeredID string, offset time.Duration, data operations.KeptnBase) operations.Event {
+		data.Project = "my-project"
+		data.Stage = "production"
+		data.Service = "my-service"
+		return operations.Event{
+			Type:           eventType,
+			Source:         source,
+			ID:             id,
+			Triggeredid:    triggeredID,
+			Shkeptncontext: "my-context",
+			Time:           start.Add(offset).Format(time.RFC3339Nano),
+			Data:           data,
+		}
+	}
+	approvalStrategy := &operations.ApprovalDetails{Pass: "automatic", Warning: "manual"}
+
+	sb := &statisticsBucket{
+		logger:     keptn.NewLogger("", "", ""),
+		correlator: newEventCorrelator(100, time.Hour),
+	}
+	sb.createNewBucket()
+
+	events := []operations.Event{
+		// automatic approval of a passed evaluation
+		newEvent("sh.keptn.event.approval.triggered", "shipyard-controller", "approval-1", "", 0, operations.KeptnBase{Result: "pass", Approval: approvalStrategy}),
+		newEvent("sh.keptn.event.approval.finished", "gatekeeper-service", "", "approval-1", 2*time.Second, operations.KeptnBase{Result: "pass"}),
+		// manual rejection of an evaluation with warnings
+		newEvent("sh.keptn.event.approval.triggered", "shipyard-controller", "approval-2", "", 0, operations.KeptnBase{Result: "warning", Approval: approvalStrategy}),
+		newEvent("sh.keptn.event.approval.finished", "gatekeeper-service", "", "approval-2", 10*time.Minute, operations.KeptnBase{Result: "fail"}),
+		// manual approval without a known approval.triggered event
+		newEvent("sh.keptn.event.approval.finished", "https://github.com/keptn/keptn/api", "", "approval-3", 0, operations.KeptnBase{Result: "pass"}),
+		// manual rejection of Keptn 0.7, which sets the decision in the approval property
+		newEvent("sh.keptn.event.approval.finished", "https://github.com/keptn/keptn/api", "", "approval-4", 0, operations.KeptnBase{Approval: &operations.ApprovalDetails{Result: "failed", Status: "succeeded"}}),
+	}
+	for _, event := range events {
+		sb.AddEvent(event)
+	}
+
+	got := sb.Statistics.Projects["my-project"].Stages["production"].Services["my-service"].Approvals
+	if got == nil {
+		t.Fatal("trackApprovals(): no approval statistics have been recorded")
+	}
+	if got.Requested != 2 || got.Approved != 2 || got.Rejected != 2 || got.Automatic != 1 || got.Manual != 3 {
+		t.Errorf("trackApprovals(): unexpected approval counts: %+v", got)
+	}
+	if got.TimesToApprove == nil || got.TimesToApprove.Count != 2 || got.TimesToApprove.Sum != 602 {
+		t.Errorf("trackApprovals(): unexpected time to approve: %+v", got.TimesToApprove)
+	}
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+)
+
+const (
+	dimensionProject      = "project"
+	dimensionService      = "service"
+	dimensionStage        = "stage"
+	dimensionKeptnService = "keptnService"
+	dimensionEventType    = "eventType"
+	// dimensionUniqueSequences counts the sketches of unique sequences that have been removed because the bucket exceeded its maximum size
+	dimensionUniqueSequences = "uniqueSequences"
+)
+
+// cardinalityLimiter caps the number of distinct projects, services, stages, Keptn services and event types per bucket,
+// so that events with random names can not grow the statistics without bound. Names beyond a limit are replaced by operations.OverflowKey
+type cardinalityLimiter struct {
+	limits map[string]int
+	// labelLimit is the limit of the values of each label dimension
+	labelLimit int
+	// seen contains the names of each dimension that have been accepted in the current bucket
+	seen map[string]map[string]bool
+	// warned contains the dimensions whose limit has already been logged in the current bucket
+	warned map[string]bool
+}
+
+func newCardinalityLimiter(env config.EnvConfig) *cardinalityLimiter {
+	limiter := &cardinalityLimiter{
+		limits: map[string]int{
+			dimensionProject:      env.MaxProjects,
+			dimensionService:      env.MaxServices,
+			dimensionStage:        env.MaxStages,
+			dimensionKeptnService: env.MaxKeptnServices,
+			dimensionEventType:    env.MaxEventTypes,
+		},
+		labelLimit: env.MaxLabelValues,
+	}
+	limiter.reset()
+	return limiter
+}
+
+// reset forgets the accepted names; it is called whenever a new bucket is created
+func (l *cardinalityLimiter) reset() {
+	l.seen = map[string]map[string]bool{}
+	l.warned = map[string]bool{}
+}
+
+// limit returns the name if it has already been accepted in the current bucket or the limit of the dimension has not been reached yet. Otherwise, operations.OverflowKey is returned
+func (l *cardinalityLimiter) limit(dimension, name string) (string, bool) {
+	limit := l.getLimit(dimension)
+	if limit <= 0 {
+		return name, true
+	}
+	if l.seen[dimension] == nil {
+		l.seen[dimension] = map[string]bool{}
+	}
+	if l.seen[dimension][name] {
+		return name, true
+	}
+	if len(l.seen[dimension]) < limit {
+		l.seen[dimension][name] = true
+		return name, true
+	}
+	return operations.OverflowKey, false
+}
+
+func (l *cardinalityLimiter) getLimit(dimension string) int {
+	if strings.HasPrefix(dimension, operations.LabelGroupPrefix) {
+		return l.labelLimit
+	}
+	return l.limits[dimension]
+}
+
+// applyCardinalityLimits replaces the names of the event that exceed the cardinality limits by operations.OverflowKey and counts the overflows
+func (sb *statisticsBucket) applyCardinalityLimits(event *operations.Event) {
+	if sb.cardinalityLimiter == nil {
+		return
+	}
+	var ok bool
+	if event.Data.Project, ok = sb.cardinalityLimiter.limit(dimensionProject, event.Data.Project); !ok {
+		sb.handleOverflow(dimensionProject)
+	}
+	// services are identified by their project, since different projects may contain services with the same name
+	if _, ok = sb.cardinalityLimiter.limit(dimensionService, event.Data.Project+"/"+event.Data.Service); !ok {
+		event.Data.Service = operations.OverflowKey
+		sb.handleOverflow(dimensionService)
+	}
+	// stages are identified by their project as well. Events without a stage are not limited
+	if event.Data.Stage != "" {
+		if _, ok = sb.cardinalityLimiter.limit(dimensionStage, event.Data.Project+"/"+event.Data.Stage); !ok {
+			event.Data.Stage = operations.OverflowKey
+			sb.handleOverflow(dimensionStage)
+		}
+	}
+	if event.Source, ok = sb.cardinalityLimiter.limit(dimensionKeptnService, event.Source); !ok {
+		sb.handleOverflow(dimensionKeptnService)
+	}
+	if event.Type, ok = sb.cardinalityLimiter.limit(dimensionEventType, event.Type); !ok {
+		sb.handleOverflow(dimensionEventType)
+	}
+}
+
+func (sb *statisticsBucket) handleOverflow(dimension string) {
+	sb.Statistics.IncreaseOverflowCount(dimension, 1)
+	metrics.CardinalityOverflows.WithLabelValues(dimension).Inc()
+	if sb.cardinalityLimiter.warned[dimension] {
+		return
+	}
+	sb.cardinalityLimiter.warned[dimension] = true
+	sb.logger.Error(fmt.Sprintf("the limit of %d distinct values for %s has been reached. Further values are counted as %s until the next bucket is created",
+		sb.cardinalityLimiter.getLimit(dimension), dimension, operations.OverflowKey))
+}
+
+// limitBucketSize removes the sketches of the unique sequences if the serialized bucket exceeds the maximum size. Otherwise,
+// MongoDB would reject the document and the whole bucket would be lost. The counters are kept, since they are bounded by the cardinality limits
+func (sb *statisticsBucket) limitBucketSize() {
+	if sb.maxBucketSize <= 0 {
+		return
+	}
+	size, err := getBucketSize(sb.Statistics)
+	if err != nil || size <= sb.maxBucketSize {
+		return
+	}
+	removed := sb.Statistics.RemoveUniqueSequences()
+	sb.Statistics.IncreaseOverflowCount(dimensionUniqueSequences, removed)
+	metrics.CardinalityOverflows.WithLabelValues(dimensionUniqueSequences).Add(float64(removed))
+	sb.logger.Error(fmt.Sprintf("the bucket has a size of %d bytes, which exceeds the limit of %d bytes. The unique sequences of %d services are not stored", size, sb.maxBucketSize, removed))
+	if size, err = getBucketSize(sb.Statistics); err == nil && size > sb.maxBucketSize {
+		sb.logger.Error(fmt.Sprintf("the bucket still has a size of %d bytes. Lower the cardinality limits to be able to store it", size))
+	}
+}
+
+func getBucketSize(statistics operations.Statistics) (int, error) {
+	document, err := bson.Marshal(statistics)
+	if err != nil {
+		return 0, err
+	}
+	return len(document), nil
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+)
+
+func Test_statisticsBucket_applyCardinalityLimits(t *testing.T) {
+	newEvent := func(project, service, source, eventType string) operations.Event {
+		return operations.Event{
+			Type:   eventType,
+			Source: source,
+			Data: operations.KeptnBase{
+				Project: project,
+				Service: service,
+			},
+		}
+	}
+
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+		cardinalityLimiter: newCardinalityLimiter(config.EnvConfig{
+			MaxProjects:      1,
+			MaxServices:      2,
+			MaxKeptnServices: 0,
+			MaxEventTypes:    1,
+		}),
+	}
+	sb.createNewBucket()
+
+	sb.AddEvent(newEvent("my-project", "carts", "helm-service", "my-type"))
+	sb.AddEvent(newEvent("my-project", "orders", "jmeter-service", "my-type"))
+	sb.AddEvent(newEvent("my-project", "payment", "lighthouse-service", "my-type-2"))
+	sb.AddEvent(newEvent("random-project", "carts", "helm-service", "my-type"))
+
+	projects := sb.Statistics.Projects
+	if len(projects) != 2 || projects["my-project"] == nil || projects[operations.OverflowKey] == nil {
+		t.Fatalf("applyCardinalityLimits(): unexpected projects: %v", projects)
+	}
+	services := projects["my-project"].Services
+	if len(services) != 3 || services["carts"] == nil || services["orders"] == nil || services[operations.OverflowKey] == nil {
+		t.Errorf("applyCardinalityLimits(): unexpected services: %v", services)
+	}
+	if services[operations.OverflowKey].Events[operations.OverflowKey] != 1 {
+		t.Errorf("applyCardinalityLimits(): event type has not been counted as %s: %v", operations.OverflowKey, services[operations.OverflowKey].Events)
+	}
+	if services[operations.OverflowKey].KeptnServiceExecutions["lighthouse-service"] == nil {
+		t.Error("applyCardinalityLimits(): Keptn services must not be limited if the limit is 0")
+	}
+
+	want := map[string]int{dimensionProject: 1, dimensionService: 2, dimensionEventType: 1}
+	for dimension, count := range want {
+		if sb.Statistics.Overflows[dimension] != count {
+			t.Errorf("applyCardinalityLimits(): want %d overflows for %s, got %d", count, dimension, sb.Statistics.Overflows[dimension])
+		}
+	}
+
+	// the limits apply per bucket
+	sb.createNewBucket()
+	sb.AddEvent(newEvent("random-project", "carts", "helm-service", "my-type-2"))
+	if sb.Statistics.Projects["random-project"] == nil || len(sb.Statistics.Overflows) != 0 {
+		t.Errorf("applyCardinalityLimits(): limits have not been reset for the new bucket: %v", sb.Statistics.Overflows)
+	}
+}
+
+func Test_statisticsBucket_applyCardinalityLimitsToKeptnServices(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+		cardinalityLimiter: newCardinalityLimiter(config.EnvConfig{
+			MaxKeptnServices: 1,
+		}),
+	}
+	sb.createNewBucket()
+
+	for _, source := range []string{"helm-service@0.8.0", "helm-service@0.8.1", "jmeter-service@0.8.0"} {
+		sb.AddEvent(operations.Event{
+			Type:   "sh.keptn.events.deployment-finished",
+			Source: source,
+			Data:   operations.KeptnBase{Project: "my-project", Service: "carts"},
+		})
+	}
+
+	project := sb.Statistics.Projects["my-project"]
+	executions := project.Services["carts"].KeptnServiceExecutions
+	for _, name := range []string{"helm-service", operations.OverflowKey} {
+		if executions[name] == nil || project.KeptnServices[name] == nil {
+			t.Errorf("applyCardinalityLimits(): want executions and inventory of %s, got %v and %v", name, executions, project.KeptnServices)
+		}
+	}
+	if len(executions) != 2 || len(project.KeptnServices) != 2 || len(project.KeptnServices["helm-service"].Versions) != 2 {
+		t.Errorf("applyCardinalityLimits(): executions and inventory must use the same Keptn service names, got %v and %v", executions, project.KeptnServices)
+	}
+}
+
+func Test_statisticsBucket_applyCardinalityLimitsToStages(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+		cardinalityLimiter: newCardinalityLimiter(config.EnvConfig{
+			MaxStages: 1,
+		}),
+	}
+	sb.createNewBucket()
+	overflowsBefore := testutil.ToFloat64(metrics.CardinalityOverflows.WithLabelValues(dimensionStage))
+
+	for _, stage := range []string{"dev", "production", "dev", ""} {
+		sb.AddEvent(operations.Event{
+			Type:   "sh.keptn.event.deployment.finished",
+			Source: "helm-service",
+			Data:   operations.KeptnBase{Project: "my-project", Stage: stage, Service: "carts"},
+		})
+	}
+
+	stages := sb.Statistics.Projects["my-project"].Stages
+	if len(stages) != 2 || stages["dev"] == nil || stages[operations.OverflowKey] == nil {
+		t.Errorf("applyCardinalityLimits(): unexpected stages: %v", stages)
+	}
+	if sb.Statistics.Overflows[dimensionStage] != 1 {
+		t.Errorf("applyCardinalityLimits(): want %d overflow for stages, got %v", 1, sb.Statistics.Overflows)
+	}
+	if got := testutil.ToFloat64(metrics.CardinalityOverflows.WithLabelValues(dimensionStage)) - overflowsBefore; got != 1 {
+		t.Errorf("applyCardinalityLimits(): want %d exported overflow for stages, got %v", 1, got)
+	}
+}
+
+func Test_statisticsBucket_limitBucketSize(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	sb.createNewBucket()
+	for _, service := range []string{"carts", "orders"} {
+		for i := 0; i < 100; i++ {
+			sb.Statistics.AddUniqueSequence("my-project", "dev", service, fmt.Sprintf("my-context-%d", i))
+		}
+	}
+	sizeWithSketches, _ := getBucketSize(sb.Statistics)
+
+	sb.maxBucketSize = sizeWithSketches
+	sb.limitBucketSize()
+	if sb.Statistics.Projects["my-project"].Stages["dev"].Services["carts"].UniqueSequences == nil {
+		t.Errorf("limitBucketSize(): want the sketches to be kept if the bucket does not exceed the limit")
+	}
+
+	sb.maxBucketSize = sizeWithSketches - 1
+	sb.limitBucketSize()
+	if sb.Statistics.Projects["my-project"].Stages["dev"].Services["carts"].UniqueSequences != nil || sb.Statistics.Overflows[dimensionUniqueSequences] != 2 {
+		t.Errorf("limitBucketSize(): want the sketches of %d services to be removed, got %v", 2, sb.Statistics.Overflows)
+	}
+	if size, _ := getBucketSize(sb.Statistics); size > sb.maxBucketSize {
+		t.Errorf("limitBucketSize(): want a size of at most %d bytes, got %d", sb.maxBucketSize, size)
+	}
+}
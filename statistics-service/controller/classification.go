@@ -0,0 +1,273 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// counterKeptnServiceExecution counts an event as an execution of the Keptn service that has sent it
+const counterKeptnServiceExecution = "keptnServiceExecution"
+
+// counterExecutedSequence counts an event as an executed sequence
+const counterExecutedSequence = "executedSequence"
+
+// PresetLegacy counts every event as a Keptn service execution (Keptn < 0.8)
+const PresetLegacy = "legacy"
+
+// PresetNextGen counts .started events as Keptn service executions, and .finished events sent by the shipyard-controller as executed sequences (Keptn >= 0.8)
+const PresetNextGen = "next-gen"
+
+// PresetAuto applies the rules of the legacy or the next-gen preset, depending on the generation of each event
+const PresetAuto = "auto"
+
+var legacyRules = []classificationRule{
+	{
+		EventType: "*",
+		Counter:   counterKeptnServiceExecution,
+		Normalize: true,
+	},
+}
+
+var nextGenRules = []classificationRule{
+	{
+		EventType: "*.started",
+		Counter:   counterKeptnServiceExecution,
+		Normalize: true,
+	},
+	{
+		EventType: "*.finished",
+		Source:    shipyardController,
+		Counter:   counterExecutedSequence,
+		Normalize: true,
+	},
+}
+
+var classificationPresets = map[string][]classificationRule{
+	PresetLegacy:  legacyRules,
+	PresetNextGen: nextGenRules,
+	PresetAuto:    append(withGeneration(legacyRules, operations.EventGenerationLegacy), withGeneration(nextGenRules, operations.EventGenerationNextGen)...),
+}
+
+func withGeneration(rules []classificationRule, generation string) []classificationRule {
+	result := []classificationRule{}
+	for _, rule := range rules {
+		rule.Generation = generation
+		result = append(result, rule)
+	}
+	return result
+}
+
+// classificationRule maps events whose type and source match the given patterns to a counter.
+// Patterns may contain '*' as a wildcard for any sequence of characters; an empty source pattern matches all sources.
+// If a generation (legacy or next-gen) is set, the rule only matches events of this generation.
+// The counted type is the event type without TrimSuffix or, if Normalize is set, the logical task name of the event type
+type classificationRule struct {
+	EventType  string `json:"eventType" yaml:"eventType"`
+	Source     string `json:"source,omitempty" yaml:"source,omitempty"`
+	Generation string `json:"generation,omitempty" yaml:"generation,omitempty"`
+	Counter    string `json:"counter" yaml:"counter"`
+	TrimSuffix string `json:"trimSuffix,omitempty" yaml:"trimSuffix,omitempty"`
+	Normalize  bool   `json:"normalize,omitempty" yaml:"normalize,omitempty"`
+
+	eventTypePattern *regexp.Regexp
+	sourcePattern    *regexp.Regexp
+}
+
+// classificationRules decides which counters are increased for an event. All rules that match an event are applied.
+// If a preset is set, its rules are applied in addition to the custom rules
+type classificationRules struct {
+	Preset string               `json:"preset,omitempty" yaml:"preset,omitempty"`
+	Rules  []classificationRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// newClassificationRulesFromPreset returns the rules of the built-in preset with the given name
+func newClassificationRulesFromPreset(preset string) (*classificationRules, error) {
+	rules := &classificationRules{Preset: preset}
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// loadClassificationRules reads the rules from a YAML or JSON file
+func loadClassificationRules(fileName string) (*classificationRules, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read classification rules file %s: %s", fileName, err.Error())
+	}
+	return parseClassificationRules(content)
+}
+
+func parseClassificationRules(content []byte) (*classificationRules, error) {
+	rules := &classificationRules{}
+	// since JSON is a subset of YAML, both formats can be parsed using the YAML parser
+	if err := yaml.Unmarshal(content, rules); err != nil {
+		return nil, fmt.Errorf("could not parse classification rules: %s", err.Error())
+	}
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// compile validates the rules, prepends the rules of the preset and compiles the patterns
+func (r *classificationRules) compile() error {
+	rules := []classificationRule{}
+	if r.Preset != "" {
+		presetRules, ok := classificationPresets[r.Preset]
+		if !ok {
+			return fmt.Errorf("unknown classification preset %s", r.Preset)
+		}
+		rules = append(rules, presetRules...)
+	}
+	rules = append(rules, r.Rules...)
+	if len(rules) == 0 {
+		return errors.New("no classification rules defined")
+	}
+
+	for index := range rules {
+		rule := &rules[index]
+		if rule.EventType == "" {
+			return fmt.Errorf("classification rule %d: eventType must not be empty", index)
+		}
+		if rule.Counter != counterKeptnServiceExecution && rule.Counter != counterExecutedSequence {
+			return fmt.Errorf("classification rule %d: unknown counter %s", index, rule.Counter)
+		}
+		if rule.Generation != "" && rule.Generation != operations.EventGenerationLegacy && rule.Generation != operations.EventGenerationNextGen {
+			return fmt.Errorf("classification rule %d: unknown generation %s", index, rule.Generation)
+		}
+		rule.eventTypePattern = operations.CompileWildcardPattern(rule.EventType)
+		if rule.Source != "" {
+			rule.sourcePattern = operations.CompileWildcardPattern(rule.Source)
+		}
+	}
+	r.Rules = rules
+	r.Preset = ""
+	return nil
+}
+
+func (rule *classificationRule) matches(event operations.Event, generation string) bool {
+	if rule.Generation != "" && rule.Generation != generation {
+		return false
+	}
+	if !rule.eventTypePattern.MatchString(event.Type) {
+		return false
+	}
+	return rule.sourcePattern == nil || rule.sourcePattern.MatchString(event.Source)
+}
+
+// apply increases the counters of all rules that match the event. It returns the types of the counted Keptn service executions and how often the executed sequence counter has been increased
+func (r *classificationRules) apply(event operations.Event, statistics *operations.Statistics) ([]string, int) {
+	executions := []string{}
+	sequences := 0
+	generation := event.GetEventGeneration()
+	for index := range r.Rules {
+		rule := &r.Rules[index]
+		if !rule.matches(event, generation) {
+			continue
+		}
+		key := strings.TrimSuffix(event.Type, rule.TrimSuffix)
+		if rule.Normalize {
+			key = operations.GetTaskName(event.Type)
+		}
+		switch rule.Counter {
+		case counterKeptnServiceExecution:
+			statistics.IncreaseKeptnServiceExecutionCount(event.Data.Project, event.Data.Stage, event.Data.Service, event.Source, key, 1)
+			executions = append(executions, key)
+		case counterExecutedSequence:
+			statistics.IncreaseExecutedSequencesCount(event.Data.Project, event.Data.Stage, event.Data.Service, 1)
+			statistics.IncreaseExecutedSequenceCountForType(event.Data.Project, event.Data.Stage, event.Data.Service, key, 1)
+			sequences++
+		}
+	}
+	return executions, sequences
+}
+
+// initClassificationRules sets the rules of the configured preset and, if a rules file is configured, loads the file and watches it for changes
+func (sb *statisticsBucket) initClassificationRules(env config.EnvConfig) {
+	rules, err := newClassificationRulesFromPreset(sb.getClassificationPreset(env))
+	if err != nil {
+		sb.logger.Error("could not use classification preset: " + err.Error())
+		rules, _ = newClassificationRulesFromPreset(PresetAuto)
+	}
+	sb.setClassificationRules(rules)
+
+	if env.ClassificationRulesFile == "" {
+		return
+	}
+	lastModified := sb.reloadClassificationRules(env.ClassificationRulesFile, time.Time{})
+	if env.ClassificationRulesReloadIntervalSeconds <= 0 {
+		sb.logger.Info("reloading of the classification rules file is disabled, since the reload interval is not positive")
+		return
+	}
+	go func() {
+		reloadTicker := time.NewTicker(time.Duration(env.ClassificationRulesReloadIntervalSeconds) * time.Second)
+		defer reloadTicker.Stop()
+		for range reloadTicker.C {
+			lastModified = sb.reloadClassificationRules(env.ClassificationRulesFile, lastModified)
+		}
+	}()
+}
+
+// getClassificationPreset returns the configured preset. The deprecated NEXT_GEN_EVENTS variable is mapped to the next-gen or the legacy preset, unless a preset is configured
+func (sb *statisticsBucket) getClassificationPreset(env config.EnvConfig) string {
+	if env.NextGenEvents == nil {
+		if env.ClassificationPreset == "" {
+			return PresetAuto
+		}
+		return env.ClassificationPreset
+	}
+	if env.ClassificationPreset != "" {
+		sb.logger.Info("NEXT_GEN_EVENTS is deprecated and ignored, since CLASSIFICATION_PRESET is set")
+		return env.ClassificationPreset
+	}
+	preset := PresetLegacy
+	if *env.NextGenEvents {
+		preset = PresetNextGen
+	}
+	sb.logger.Info(fmt.Sprintf("NEXT_GEN_EVENTS is deprecated, use CLASSIFICATION_PRESET=%s instead", preset))
+	return preset
+}
+
+// reloadClassificationRules loads the rules file if it has been modified since the given time and returns its modification time.
+// If the file can not be loaded, the current rules are kept
+func (sb *statisticsBucket) reloadClassificationRules(fileName string, lastModified time.Time) time.Time {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		sb.logger.Error("could not access classification rules file: " + err.Error())
+		return lastModified
+	}
+	if info.ModTime().Equal(lastModified) {
+		return lastModified
+	}
+	rules, err := loadClassificationRules(fileName)
+	if err != nil {
+		sb.logger.Error(err.Error() + ". Keeping the current classification rules")
+		return info.ModTime()
+	}
+	sb.setClassificationRules(rules)
+	sb.logger.Info("loaded classification rules from " + fileName)
+	return info.ModTime()
+}
+
+func (sb *statisticsBucket) setClassificationRules(rules *classificationRules) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	sb.classificationRules = rules
+}
+
+// getClassificationRules returns the current rules. If no rules have been set, the auto preset is used
+func (sb *statisticsBucket) getClassificationRules() *classificationRules {
+	if sb.classificationRules == nil {
+		sb.classificationRules, _ = newClassificationRulesFromPreset(PresetAuto)
+	}
+	return sb.classificationRules
+}
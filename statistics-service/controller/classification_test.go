@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_parseClassificationRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantRules int
+		wantErr   bool
+	}{
+		{
+			name: "yaml with preset and custom rule",
+			content: `preset: next-gen
+rules:
+  - eventType: "sh.keptn.event.*.triggered"
+    source: "https://github.com/keptn/keptn/api"
+    counter: executedSequence
+    trimSuffix: ".triggered"
+`,
+			wantRules: 3,
+		},
+		{
+			name:      "json",
+			content:   `{"rules": [{"eventType": "*", "counter": "keptnServiceExecution"}]}`,
+			wantRules: 1,
+		},
+		{
+			name:    "unknown preset",
+			content: `preset: my-preset`,
+			wantErr: true,
+		},
+		{
+			name: "unknown counter",
+			content: `rules:
+  - eventType: "*"
+    counter: my-counter
+`,
+			wantErr: true,
+		},
+		{
+			name:    "no rules",
+			content: `rules: []`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseClassificationRules([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClassificationRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got.Rules) != tt.wantRules {
+				t.Errorf("parseClassificationRules(): want %d rules, got %d", tt.wantRules, len(got.Rules))
+			}
+		})
+	}
+}
+
+func Test_classificationRules_apply(t *testing.T) {
+	rules, err := parseClassificationRules([]byte(`rules:
+  - eventType: "sh.keptn.event.*.started"
+    source: "*-service"
+    counter: keptnServiceExecution
+    trimSuffix: ".started"
+  - eventType: "sh.keptn.event.*.finished"
+    source: "shipyard-controller"
+    counter: executedSequence
+    trimSuffix: ".finished"
+`))
+	if err != nil {
+		t.Fatalf("parseClassificationRules(): unexpected error: %v", err)
+	}
+
+	statistics := &operations.Statistics{}
+	events := []operations.Event{
+		{Type: "sh.keptn.event.deployment.started", Source: "helm-service"},
+		{Type: "sh.keptn.event.deployment.started", Source: "my-deployer"},
+		{Type: "sh.keptn.event.deployment.finished", Source: "helm-service"},
+		{Type: "sh.keptn.event.dev.delivery.finished", Source: "shipyard-controller"},
+	}
+	for _, event := range events {
+		event.Data = operations.KeptnBase{Project: "my-project", Service: "my-service"}
+		rules.apply(event, statistics)
+	}
+
+	service := statistics.Projects["my-project"].Services["my-service"]
+	if len(service.KeptnServiceExecutions) != 1 || service.KeptnServiceExecutions["helm-service"].Executions["sh.keptn.event.deployment"] != 1 {
+		t.Errorf("classificationRules.apply(): unexpected Keptn service executions: %v", service.KeptnServiceExecutions)
+	}
+	if service.ExecutedSequences != 1 || service.ExecutedSequencesPerType["sh.keptn.event.dev.delivery"] != 1 {
+		t.Errorf("classificationRules.apply(): unexpected executed sequences: %d, %v", service.ExecutedSequences, service.ExecutedSequencesPerType)
+	}
+}
+
+func Test_statisticsBucket_reloadClassificationRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "classification")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "rules.yaml")
+
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+
+	writeRules := func(content string, modTime time.Time) {
+		if err := ioutil.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	firstModTime := time.Now().Add(-time.Hour)
+	writeRules("preset: legacy", firstModTime)
+	lastModified := sb.reloadClassificationRules(fileName, time.Time{})
+	if !lastModified.Equal(firstModTime) || len(sb.classificationRules.Rules) != 1 {
+		t.Fatalf("reloadClassificationRules(): rules file has not been loaded")
+	}
+	initialRules := sb.classificationRules
+
+	// invalid rules are ignored
+	writeRules("preset: unknown", firstModTime.Add(time.Minute))
+	lastModified = sb.reloadClassificationRules(fileName, lastModified)
+	if sb.classificationRules != initialRules {
+		t.Error("reloadClassificationRules(): invalid rules should not replace the current rules")
+	}
+
+	writeRules("preset: next-gen", firstModTime.Add(2*time.Minute))
+	sb.reloadClassificationRules(fileName, lastModified)
+	if len(sb.classificationRules.Rules) != 2 {
+		t.Errorf("reloadClassificationRules(): modified rules file has not been reloaded")
+	}
+}
+
+func Test_statisticsBucket_AddEventOfMixedGenerations(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	sb.createNewBucket()
+
+	events := []operations.Event{
+		// Keptn 0.7
+		{Type: "sh.keptn.events.deployment-finished", Source: "helm-service", Specversion: "0.2"},
+		// Keptn 0.8
+		{Type: "sh.keptn.event.deployment.triggered", Source: "shipyard-controller", Specversion: "1.0"},
+		{Type: "sh.keptn.event.deployment.started", Source: "helm-service", Specversion: "1.0"},
+		{Type: "sh.keptn.event.deployment.finished", Source: "helm-service", Specversion: "1.0"},
+		{Type: "sh.keptn.event.dev.delivery.finished", Source: "shipyard-controller", Specversion: "1.0"},
+	}
+	for _, event := range events {
+		event.Data = operations.KeptnBase{Project: "my-project", Service: "my-service"}
+		sb.AddEvent(event)
+	}
+
+	service := sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"]
+	if got := service.KeptnServiceExecutions["helm-service"].Executions; len(got) != 1 || got["deployment"] != 2 {
+		t.Errorf("AddEvent(): expected 2 deployments of helm-service, got %v", got)
+	}
+	if _, ok := service.KeptnServiceExecutions["shipyard-controller"]; ok {
+		t.Error("AddEvent(): events of the shipyard-controller should not be counted as Keptn service executions")
+	}
+	if service.ExecutedSequences != 1 || service.ExecutedSequencesPerType["delivery"] != 1 {
+		t.Errorf("AddEvent(): unexpected executed sequences: %d, %v", service.ExecutedSequences, service.ExecutedSequencesPerType)
+	}
+	// the stage is derived from the type of the sequence event
+	stage := sb.Statistics.Projects["my-project"].Stages["dev"]
+	if stage == nil || stage.Services["my-service"].ExecutedSequencesPerType["delivery"] != 1 {
+		t.Errorf("AddEvent(): executed sequence has not been recorded for stage dev")
+	}
+}
+
+func Test_statisticsBucket_getClassificationPreset(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name string
+		env  config.EnvConfig
+		want string
+	}{
+		{name: "default", env: config.EnvConfig{}, want: PresetAuto},
+		{name: "configured preset", env: config.EnvConfig{ClassificationPreset: PresetLegacy}, want: PresetLegacy},
+		{name: "deprecated next-gen events", env: config.EnvConfig{NextGenEvents: &enabled}, want: PresetNextGen},
+		{name: "deprecated legacy events", env: config.EnvConfig{NextGenEvents: &disabled}, want: PresetLegacy},
+		{name: "configured preset overrides the deprecated variable", env: config.EnvConfig{ClassificationPreset: PresetAuto, NextGenEvents: &disabled}, want: PresetAuto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &statisticsBucket{logger: keptn.NewLogger("", "", "")}
+			if got := sb.getClassificationPreset(tt.env); got != tt.want {
+				t.Errorf("getClassificationPreset() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"container/list"
+	"time"
+)
+
+type pendingCorrelation struct {
+	key       string
+	eventTime time.Time
+	value     string
+	expiresAt time.Time
+}
+
+// eventCorrelator keeps track of events (e.g. .triggered or .started events) that are waiting for a corresponding event (e.g. a .finished event).
+// The number of pending correlations is bounded: if the limit is reached, the oldest correlation is dropped.
+// Correlations that have not been resolved within the timeout are removed
+type eventCorrelator struct {
+	pending    map[string]*list.Element
+	order      *list.List
+	maxEntries int
+	timeout    time.Duration
+}
+
+func newEventCorrelator(maxEntries int, timeout time.Duration) *eventCorrelator {
+	return &eventCorrelator{
+		pending:    map[string]*list.Element{},
+		order:      list.New(),
+		maxEntries: maxEntries,
+		timeout:    timeout,
+	}
+}
+
+// add registers an event that is waiting to be correlated. Existing correlations with the same key are replaced
+func (c *eventCorrelator) add(key string, eventTime time.Time, now time.Time) {
+	c.addWithValue(key, eventTime, "", now)
+}
+
+// addWithValue registers an event that is waiting to be correlated, together with a value that is needed when the correlation is resolved
+func (c *eventCorrelator) addWithValue(key string, eventTime time.Time, value string, now time.Time) {
+	if c.maxEntries <= 0 {
+		return
+	}
+	c.remove(key)
+	c.expire(now)
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Front().Value.(*pendingCorrelation).key)
+	}
+	c.pending[key] = c.order.PushBack(&pendingCorrelation{
+		key:       key,
+		eventTime: eventTime,
+		value:     value,
+		expiresAt: now.Add(c.timeout),
+	})
+}
+
+// get returns the time of the pending event with the given key
+func (c *eventCorrelator) get(key string, now time.Time) (time.Time, bool) {
+	c.expire(now)
+	element, ok := c.pending[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	return element.Value.(*pendingCorrelation).eventTime, true
+}
+
+// resolve returns the time of the pending event with the given key and removes it
+func (c *eventCorrelator) resolve(key string, now time.Time) (time.Time, bool) {
+	eventTime, _, ok := c.resolveWithValue(key, now)
+	return eventTime, ok
+}
+
+// resolveWithValue returns the time and the value of the pending event with the given key and removes it
+func (c *eventCorrelator) resolveWithValue(key string, now time.Time) (time.Time, string, bool) {
+	c.expire(now)
+	element, ok := c.pending[key]
+	if !ok {
+		return time.Time{}, "", false
+	}
+	correlation := element.Value.(*pendingCorrelation)
+	c.remove(key)
+	return correlation.eventTime, correlation.value, true
+}
+
+func (c *eventCorrelator) remove(key string) {
+	if element, ok := c.pending[key]; ok {
+		c.order.Remove(element)
+		delete(c.pending, key)
+	}
+}
+
+// expire removes all correlations that have timed out. Since all correlations use the same timeout, the list is ordered by expiration time
+func (c *eventCorrelator) expire(now time.Time) {
+	for c.order.Len() > 0 {
+		oldest := c.order.Front().Value.(*pendingCorrelation)
+		if oldest.expiresAt.After(now) {
+			return
+		}
+		c.remove(oldest.key)
+	}
+}
+
+func (c *eventCorrelator) size() int {
+	return c.order.Len()
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_eventCorrelator(t *testing.T) {
+	now := time.Now()
+	c := newEventCorrelator(2, time.Minute)
+
+	c.add("first", now, now)
+	c.add("second", now.Add(time.Second), now)
+	c.add("third", now.Add(2*time.Second), now)
+
+	if c.size() != 2 {
+		t.Errorf("eventCorrelator: expected %d pending correlations, got %d", 2, c.size())
+	}
+	if _, ok := c.get("first", now); ok {
+		t.Error("eventCorrelator: oldest correlation should have been dropped")
+	}
+
+	eventTime, ok := c.resolve("second", now)
+	if !ok || !eventTime.Equal(now.Add(time.Second)) {
+		t.Errorf("eventCorrelator.resolve(): got %v, %v", eventTime, ok)
+	}
+	if _, ok := c.get("second", now); ok {
+		t.Error("eventCorrelator.resolve(): correlation should have been removed")
+	}
+
+	c.addWithValue("fourth", now, "my-value", now)
+	if _, value, ok := c.resolveWithValue("fourth", now); !ok || value != "my-value" {
+		t.Errorf("eventCorrelator.resolveWithValue(): got %v, %v", value, ok)
+	}
+
+	if _, ok := c.get("third", now.Add(2*time.Minute)); ok {
+		t.Error("eventCorrelator: correlation should have expired")
+	}
+	if c.size() != 0 {
+		t.Errorf("eventCorrelator: expected %d pending correlations, got %d", 0, c.size())
+	}
+}
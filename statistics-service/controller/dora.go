@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"strings"
+	"time"
+)
+
+// trackDora derives the signals for the DORA metrics from the event:
+//   - a configuration change marks the start of a change; its lead time ends with the successful deployment of the change
+//   - finished deployments are counted, and they are considered as failed if the deployment or the subsequent evaluation fails
+//   - opened problems are counted as incidents; the time to restore ends with the closing of the problem
+func (sb *statisticsBucket) trackDora(event operations.Event, now time.Time) {
+	eventTime, err := event.GetTime()
+	if err != nil {
+		eventTime = now
+	}
+	deploymentKey := "deployment/" + event.Shkeptncontext + "/" + event.Data.Stage
+
+	switch {
+	case isConfigurationChangeEvent(event.Type):
+		if event.Shkeptncontext == "" {
+			return
+		}
+		// a change is promoted through several stages within the same Keptn context, so only the first event marks the start of the change
+		changeKey := "change/" + event.Shkeptncontext
+		if _, ok := sb.correlator.get(changeKey, now); !ok {
+			sb.correlator.add(changeKey, eventTime, now)
+		}
+	case isDeploymentFinishedEvent(event.Type):
+		failed := isFailedResult(event.Data.Result, event.Data.Status)
+		sb.Statistics.AddDeployment(event.Data.Project, event.Data.Stage, event.Data.Service, failed)
+		if failed || event.Shkeptncontext == "" {
+			return
+		}
+		if changeTime, ok := sb.correlator.get("change/"+event.Shkeptncontext, now); ok {
+			sb.Statistics.AddLeadTime(event.Data.Project, event.Data.Stage, event.Data.Service, eventTime.Sub(changeTime))
+		}
+		sb.correlator.add(deploymentKey, eventTime, now)
+	case isEvaluationFinishedEvent(event.Type):
+		if _, ok := sb.correlator.resolve(deploymentKey, now); !ok {
+			return
+		}
+		result := event.Data.Result
+		if event.Data.Evaluation != nil && event.Data.Evaluation.Result != "" {
+			result = event.Data.Evaluation.Result
+		} else if event.Data.EvaluationDetails != nil && event.Data.EvaluationDetails.Result != "" {
+			result = event.Data.EvaluationDetails.Result
+		}
+		if isFailedResult(result, event.Data.Status) {
+			sb.Statistics.IncreaseFailedDeploymentCount(event.Data.Project, event.Data.Stage, event.Data.Service, 1)
+		}
+	case isProblemOpenEvent(event):
+		sb.Statistics.IncreaseIncidentCount(event.Data.Project, event.Data.Stage, event.Data.Service, 1)
+		sb.correlator.add(getProblemCorrelationKey(event), eventTime, now)
+	case isProblemClosedEvent(event):
+		if openTime, ok := sb.correlator.resolve(getProblemCorrelationKey(event), now); ok {
+			sb.Statistics.AddTimeToRestore(event.Data.Project, event.Data.Stage, event.Data.Service, eventTime.Sub(openTime))
+		}
+	}
+}
+
+// isConfigurationChangeEvent returns true for events that start the delivery of a new artifact
+func isConfigurationChangeEvent(eventType string) bool {
+	if eventType == "sh.keptn.event.configuration.change" {
+		return true
+	}
+	return strings.HasPrefix(eventType, "sh.keptn.event.") && strings.HasSuffix(eventType, "delivery.triggered")
+}
+
+func isDeploymentFinishedEvent(eventType string) bool {
+	return eventType == "sh.keptn.event.deployment.finished" || eventType == "sh.keptn.events.deployment-finished"
+}
+
+func isProblemOpenEvent(event operations.Event) bool {
+	if event.Type == "sh.keptn.event.problem.open" {
+		return true
+	}
+	return event.Type == "sh.keptn.events.problem" && strings.EqualFold(event.Data.State, "OPEN")
+}
+
+func isProblemClosedEvent(event operations.Event) bool {
+	if event.Type == "sh.keptn.event.problem.closed" || event.Type == "sh.keptn.event.problem.resolved" {
+		return true
+	}
+	return event.Type == "sh.keptn.events.problem" && (strings.EqualFold(event.Data.State, "RESOLVED") || strings.EqualFold(event.Data.State, "CLOSED"))
+}
+
+func getProblemCorrelationKey(event operations.Event) string {
+	if event.Data.ProblemID != "" {
+		return "problem/" + event.Data.Project + "/" + event.Data.ProblemID
+	}
+	return "problem/" + event.Shkeptncontext
+}
+
+func isFailedResult(result, status string) bool {
+	return strings.EqualFold(result, "fail") || strings.EqualFold(result, "failed") || strings.EqualFold(status, "errored")
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"github.com/go-test/deep"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+	"time"
+)
+
+func Test_statisticsBucket_trackDora(t *testing.T) {
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	newEvent := func(eventType, context, stage string, offset time.Duration, data operations.KeptnBase) operations.Event {
+		data.Project = "my-project"
+		data.Stage = stage
+		data.Service = "my-service"
+		return operations.Event{
+			Type:           eventType,
+			Source:         "my-source",
+			Shkeptncontext: context,
+			Time:           start.Add(offset).Format(time.RFC3339Nano),
+			Data:           data,
+		}
+	}
+
+	sb := &statisticsBucket{
+		logger:     keptn.NewLogger("", "", ""),
+		correlator: newEventCorrelator(100, 24*time.Hour),
+	}
+	sb.createNewBucket()
+
+	events := []operations.Event{
+		// first change: deployed to dev and hardening, evaluation in hardening fails
+		newEvent("sh.keptn.event.dev.delivery.triggered", "ctx-1", "dev", 0, operations.KeptnBase{}),
+		newEvent("sh.keptn.event.deployment.finished", "ctx-1", "dev", 10*time.Minute, operations.KeptnBase{Result: "pass"}),
+		newEvent("sh.keptn.event.hardening.delivery.triggered", "ctx-1", "hardening", 20*time.Minute, operations.KeptnBase{}),
+		newEvent("sh.keptn.event.deployment.finished", "ctx-1", "hardening", 30*time.Minute, operations.KeptnBase{Result: "pass"}),
+		newEvent("sh.keptn.event.evaluation.finished", "ctx-1", "hardening", 35*time.Minute, operations.KeptnBase{Result: "fail"}),
+		// second change: deployment to dev fails
+		newEvent("sh.keptn.event.configuration.change", "ctx-2", "dev", 0, operations.KeptnBase{}),
+		newEvent("sh.keptn.events.deployment-finished", "ctx-2", "dev", time.Minute, operations.KeptnBase{Result: "fail"}),
+		// problem in hardening that is closed after one hour
+		newEvent("sh.keptn.events.problem", "ctx-3", "hardening", 0, operations.KeptnBase{State: "OPEN", ProblemID: "123"}),
+		newEvent("sh.keptn.events.problem", "ctx-4", "hardening", time.Hour, operations.KeptnBase{State: "CLOSED", ProblemID: "123"}),
+	}
+	for _, event := range events {
+		sb.trackDora(event, start)
+	}
+
+	leadTimes := operations.NewDurationStatistics()
+	leadTimes.Add(10 * time.Minute)
+	hardeningLeadTimes := operations.NewDurationStatistics()
+	hardeningLeadTimes.Add(30 * time.Minute)
+	timesToRestore := operations.NewDurationStatistics()
+	timesToRestore.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		stage string
+		want  *operations.DoraStatistics
+	}{
+		{
+			name:  "dev",
+			stage: "dev",
+			want: &operations.DoraStatistics{
+				Deployments:       2,
+				FailedDeployments: 1,
+				LeadTimes:         leadTimes,
+			},
+		},
+		{
+			name:  "hardening",
+			stage: "hardening",
+			want: &operations.DoraStatistics{
+				Deployments:       1,
+				FailedDeployments: 1,
+				LeadTimes:         hardeningLeadTimes,
+				Incidents:         1,
+				TimesToRestore:    timesToRestore,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sb.Statistics.Projects["my-project"].Stages[tt.stage].Services["my-service"].Dora
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Error("trackDora(): did not get expected DORA statistics")
+				for _, d := range diff {
+					t.Log(d)
+				}
+			}
+		})
+	}
+
+	if got := sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"].Dora.Deployments; got != 3 {
+		t.Errorf("trackDora(): expected %d deployments across all stages, got %d", 3, got)
+	}
+}
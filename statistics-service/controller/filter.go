@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+const (
+	filterDimensionProject   = "project"
+	filterDimensionService   = "service"
+	filterDimensionSource    = "source"
+	filterDimensionEventType = "eventType"
+)
+
+// filterDimensions contains the dimensions in the order in which they are checked
+var filterDimensions = []string{filterDimensionProject, filterDimensionService, filterDimensionSource, filterDimensionEventType}
+
+// filterList contains the allow and deny patterns of a dimension.
+// Patterns may contain '*' as a wildcard for any sequence of characters; patterns enclosed in slashes (e.g. /^test-.*$/) are regular expressions
+type filterList struct {
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+
+	includePatterns []*regexp.Regexp
+	excludePatterns []*regexp.Regexp
+}
+
+// ingestionFilter decides which events are counted. An event is dropped if one of its values does not match the include patterns of its dimension (if there are any),
+// or if it matches one of the exclude patterns
+type ingestionFilter struct {
+	Projects   filterList `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Services   filterList `json:"services,omitempty" yaml:"services,omitempty"`
+	Sources    filterList `json:"sources,omitempty" yaml:"sources,omitempty"`
+	EventTypes filterList `json:"eventTypes,omitempty" yaml:"eventTypes,omitempty"`
+}
+
+// newIngestionFilter creates the filter from the environment and, if configured, adds the patterns of the filter file
+func newIngestionFilter(env config.EnvConfig) (*ingestionFilter, error) {
+	filter := &ingestionFilter{}
+	if env.IngestionFiltersFile != "" {
+		content, err := ioutil.ReadFile(env.IngestionFiltersFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ingestion filters file %s: %s", env.IngestionFiltersFile, err.Error())
+		}
+		// since JSON is a subset of YAML, both formats can be parsed using the YAML parser
+		if err := yaml.Unmarshal(content, filter); err != nil {
+			return nil, fmt.Errorf("could not parse ingestion filters: %s", err.Error())
+		}
+	}
+	filter.Projects.add(env.IncludeProjects, env.ExcludeProjects)
+	filter.Services.add(env.IncludeServices, env.ExcludeServices)
+	filter.Sources.add(env.IncludeSources, env.ExcludeSources)
+	filter.EventTypes.add(env.IncludeEventTypes, env.ExcludeEventTypes)
+
+	for _, dimension := range filterDimensions {
+		if err := filter.getList(dimension).compile(); err != nil {
+			return nil, fmt.Errorf("invalid %s filter: %s", dimension, err.Error())
+		}
+	}
+	return filter, nil
+}
+
+func (l *filterList) add(include, exclude []string) {
+	l.Include = append(l.Include, include...)
+	l.Exclude = append(l.Exclude, exclude...)
+}
+
+func (l *filterList) compile() error {
+	var err error
+	if l.includePatterns, err = compileFilterPatterns(l.Include); err != nil {
+		return err
+	}
+	l.excludePatterns, err = compileFilterPatterns(l.Exclude)
+	return err
+}
+
+func compileFilterPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			compiled, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %s: %s", pattern, err.Error())
+			}
+			result = append(result, compiled)
+			continue
+		}
+		result = append(result, operations.CompileWildcardPattern(pattern))
+	}
+	return result, nil
+}
+
+func (l *filterList) accepts(value string) bool {
+	if len(l.includePatterns) > 0 && !matchesAny(l.includePatterns, value) {
+		return false
+	}
+	return !matchesAny(l.excludePatterns, value)
+}
+
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ingestionFilter) getList(dimension string) *filterList {
+	switch dimension {
+	case filterDimensionProject:
+		return &f.Projects
+	case filterDimensionService:
+		return &f.Services
+	case filterDimensionSource:
+		return &f.Sources
+	default:
+		return &f.EventTypes
+	}
+}
+
+// rejects returns the first dimension whose filter does not accept the event, or false if the event is accepted
+func (f *ingestionFilter) rejects(event operations.Event) (string, bool) {
+	values := map[string]string{
+		filterDimensionProject:   event.Data.Project,
+		filterDimensionService:   event.Data.Service,
+		filterDimensionSource:    event.Source,
+		filterDimensionEventType: event.Type,
+	}
+	for _, dimension := range filterDimensions {
+		if !f.getList(dimension).accepts(values[dimension]) {
+			return dimension, true
+		}
+	}
+	return "", false
+}
+
+// initIngestionFilter sets the filter defined by the environment. If the filter is invalid, an error is returned and no filter is set
+func (sb *statisticsBucket) initIngestionFilter(env config.EnvConfig) error {
+	filter, err := newIngestionFilter(env)
+	if err != nil {
+		return err
+	}
+	sb.ingestionFilter = filter
+	return nil
+}
+
+// isFiltered returns true if the event is rejected by the ingestion filter. Rejected events are counted per dimension
+func (sb *statisticsBucket) isFiltered(event operations.Event) bool {
+	if sb.ingestionFilter == nil {
+		return false
+	}
+	dimension, rejected := sb.ingestionFilter.rejects(event)
+	if rejected {
+		sb.Statistics.IncreaseFilteredEventCount(dimension, 1)
+	}
+	return rejected
+}
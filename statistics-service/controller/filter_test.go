@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_newIngestionFilter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "filters.yaml")
+	content := `
+projects:
+  exclude:
+  - "/^test-[0-9]+$/"
+eventTypes:
+  exclude:
+  - "*get-sli*"
+`
+	if err := ioutil.WriteFile(fileName, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := newIngestionFilter(config.EnvConfig{
+		IngestionFiltersFile: fileName,
+		IncludeSources:       []string{"*-service", "shipyard-controller"},
+		ExcludeEventTypes:    []string{"sh.keptn.event.monitoring.configure"},
+	})
+	if err != nil {
+		t.Fatalf("newIngestionFilter(): unexpected error: %s", err.Error())
+	}
+
+	newEvent := func(project, source, eventType string) operations.Event {
+		return operations.Event{
+			Type:   eventType,
+			Source: source,
+			Data:   operations.KeptnBase{Project: project, Service: "carts"},
+		}
+	}
+	tests := []struct {
+		name          string
+		event         operations.Event
+		wantDimension string
+		wantRejected  bool
+	}{
+		{
+			name:  "accepted",
+			event: newEvent("sockshop", "helm-service", "sh.keptn.event.deployment.finished"),
+		},
+		{
+			name:  "regular expression does not match",
+			event: newEvent("test-project", "helm-service", "sh.keptn.event.deployment.finished"),
+		},
+		{
+			name:          "excluded project",
+			event:         newEvent("test-42", "helm-service", "sh.keptn.event.deployment.finished"),
+			wantDimension: filterDimensionProject,
+			wantRejected:  true,
+		},
+		{
+			name:          "source not included",
+			event:         newEvent("sockshop", "my-ci", "sh.keptn.event.deployment.finished"),
+			wantDimension: filterDimensionSource,
+			wantRejected:  true,
+		},
+		{
+			name:          "event type excluded by file",
+			event:         newEvent("sockshop", "lighthouse-service", "sh.keptn.internal.event.get-sli.done"),
+			wantDimension: filterDimensionEventType,
+			wantRejected:  true,
+		},
+		{
+			name:          "event type excluded by environment",
+			event:         newEvent("sockshop", "dynatrace-service", "sh.keptn.event.monitoring.configure"),
+			wantDimension: filterDimensionEventType,
+			wantRejected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDimension, gotRejected := filter.rejects(tt.event)
+			if gotDimension != tt.wantDimension || gotRejected != tt.wantRejected {
+				t.Errorf("rejects() = %s, %v, want %s, %v", gotDimension, gotRejected, tt.wantDimension, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func Test_newIngestionFilter_InvalidPattern(t *testing.T) {
+	if _, err := newIngestionFilter(config.EnvConfig{ExcludeProjects: []string{"/[/"}}); err == nil {
+		t.Error("newIngestionFilter(): expected an error for an invalid regular expression")
+	}
+}
+
+func Test_statisticsBucket_initIngestionFilter_Invalid(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	if err := sb.initIngestionFilter(config.EnvConfig{IngestionFiltersFile: "/does/not/exist.yaml"}); err == nil {
+		t.Error("initIngestionFilter(): expected an error for a missing filter file")
+	}
+	if err := sb.initIngestionFilter(config.EnvConfig{IncludeServices: []string{"/[/"}}); err == nil || sb.ingestionFilter != nil {
+		t.Error("initIngestionFilter(): expected an error and no filter for an invalid pattern")
+	}
+}
+
+func Test_statisticsBucket_isFiltered(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	if err := sb.initIngestionFilter(config.EnvConfig{ExcludeProjects: []string{"test-*"}}); err != nil {
+		t.Fatalf("initIngestionFilter(): unexpected error: %s", err.Error())
+	}
+	sb.createNewBucket()
+
+	sb.AddEvent(operations.Event{Type: "my-type", Source: "my-source", Data: operations.KeptnBase{Project: "test-project", Service: "carts"}})
+	sb.AddEvent(operations.Event{Type: "my-type", Source: "my-source", Data: operations.KeptnBase{Project: "sockshop", Service: "carts"}})
+
+	if sb.Statistics.Projects["test-project"] != nil || sb.Statistics.Projects["sockshop"] == nil {
+		t.Errorf("isFiltered(): unexpected projects: %v", sb.Statistics.Projects)
+	}
+	if sb.Statistics.FilteredEvents[filterDimensionProject] != 1 {
+		t.Errorf("isFiltered(): want %d filtered events, got %v", 1, sb.Statistics.FilteredEvents)
+	}
+}
+
+func Test_statisticsBucket_isFilteredIgnoresVersion(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	if err := sb.initIngestionFilter(config.EnvConfig{ExcludeSources: []string{"helm-service"}}); err != nil {
+		t.Fatalf("initIngestionFilter(): unexpected error: %s", err.Error())
+	}
+	sb.createNewBucket()
+
+	sb.AddEvent(operations.Event{Type: "my-type", Source: "helm-service@0.8.0", Data: operations.KeptnBase{Project: "sockshop", Service: "carts"}})
+	sb.AddEvent(operations.Event{Type: "my-type", Source: "jmeter-service@0.8.0", Data: operations.KeptnBase{Project: "sockshop", Service: "carts"}})
+
+	if sb.Statistics.FilteredEvents[filterDimensionSource] != 1 {
+		t.Errorf("isFiltered(): want the versioned source to be filtered, got %v", sb.Statistics.FilteredEvents)
+	}
+	if sb.Statistics.Projects["sockshop"].Services["carts"].Events["my-type"] != 1 {
+		t.Errorf("isFiltered(): want %d counted event, got %v", 1, sb.Statistics.Projects["sockshop"].Services["carts"].Events)
+	}
+}
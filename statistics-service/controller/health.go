@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"time"
+)
+
+const (
+	healthCheckBucketRotation = "bucketRotation"
+	healthCheckMongoDB        = "mongodb"
+)
+
+// GetLiveness checks whether the goroutine that stores the buckets is running. The database is not checked,
+// since restarting the service does not fix an unavailable database
+func (sb *statisticsBucket) GetLiveness(now time.Time) operations.HealthResponse {
+	result := sb.newHealthResponse()
+	result.AddCheck(operations.NewHealthCheck(healthCheckBucketRotation, sb.checkBucketRotation(now)))
+	return result
+}
+
+// GetReadiness checks whether the database can be reached. The result of the last flush is only reported, since
+// a bucket that could not be stored is kept and stored with the next flush
+func (sb *statisticsBucket) GetReadiness() operations.HealthResponse {
+	result := sb.newHealthResponse()
+	result.AddCheck(operations.NewHealthCheck(healthCheckMongoDB, sb.checkDatabase()))
+	return result
+}
+
+func (sb *statisticsBucket) newHealthResponse() operations.HealthResponse {
+	sb.healthLock.Lock()
+	defer sb.healthLock.Unlock()
+	result := operations.HealthResponse{
+		Status:      operations.HealthStatusOK,
+		Checks:      []operations.HealthCheck{},
+		BucketStart: sb.cutoffTime,
+	}
+	if !sb.lastSuccessfulFlush.IsZero() {
+		lastSuccessfulFlush := sb.lastSuccessfulFlush
+		result.LastSuccessfulFlush = &lastSuccessfulFlush
+	}
+	if sb.lastFlushError != nil {
+		result.LastFlushFailed = true
+		result.LastFlushError = sb.lastFlushError.Error()
+	}
+	return result
+}
+
+// checkBucketRotation returns an error if no bucket has been created or kept within twice the aggregation interval
+func (sb *statisticsBucket) checkBucketRotation(now time.Time) error {
+	if sb.aggregationInterval <= 0 {
+		return nil
+	}
+	sb.healthLock.Lock()
+	lastRotation := sb.lastRotation
+	sb.healthLock.Unlock()
+	if age := now.Sub(lastRotation); age > 2*sb.aggregationInterval {
+		return fmt.Errorf("the current bucket has been rotated %s ago, but buckets are rotated every %s", age.Round(time.Second), sb.aggregationInterval)
+	}
+	return nil
+}
+
+func (sb *statisticsBucket) checkDatabase() error {
+	if sb.StatisticsRepo == nil {
+		return errors.New("no database configured")
+	}
+	return sb.StatisticsRepo.CheckConnection()
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"errors"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+	"time"
+)
+
+func Test_statisticsBucket_GetLiveness(t *testing.T) {
+	sb := &statisticsBucket{
+		logger:              keptn.NewLogger("", "", ""),
+		aggregationInterval: 30 * time.Minute,
+	}
+	sb.createNewBucket()
+
+	if got := sb.GetLiveness(time.Now()); !got.IsHealthy() {
+		t.Errorf("GetLiveness(): want a healthy service, got %+v", got)
+	}
+	if got := sb.GetLiveness(time.Now().Add(61 * time.Minute)); got.IsHealthy() || got.Checks[0].Message == "" {
+		t.Errorf("GetLiveness(): want a failed check if the bucket has not been rotated, got %+v", got)
+	}
+}
+
+func Test_statisticsBucket_GetReadiness(t *testing.T) {
+	var connectionErr error
+	var storeErr error
+	repo := &MockStatisticsRepo{
+		CheckConnectionFunc: func() error {
+			return connectionErr
+		},
+		StoreStatisticsFunc: func(statistics operations.Statistics) error {
+			return storeErr
+		},
+	}
+	sb := &statisticsBucket{
+		StatisticsRepo: repo,
+		logger:         keptn.NewLogger("", "", ""),
+	}
+	sb.createNewBucket()
+
+	got := sb.GetReadiness()
+	if !got.IsHealthy() || got.LastSuccessfulFlush != nil || len(got.Checks) != 1 {
+		t.Errorf("GetReadiness(): want a ready service without a flush, got %+v", got)
+	}
+
+	sb.storeCurrentBucket()
+	got = sb.GetReadiness()
+	if !got.IsHealthy() || got.LastSuccessfulFlush == nil {
+		t.Errorf("GetReadiness(): want the time of the last flush, got %+v", got)
+	}
+
+	storeErr = errors.New("connection refused")
+	sb.storeCurrentBucket()
+	got = sb.GetReadiness()
+	if !got.IsHealthy() || !got.LastFlushFailed || got.LastFlushError != "connection refused" || got.LastSuccessfulFlush == nil {
+		t.Errorf("GetReadiness(): want a ready service that reports the failed flush, got %+v", got)
+	}
+
+	connectionErr = errors.New("server selection timeout")
+	got = sb.GetReadiness()
+	if got.IsHealthy() || got.Checks[0].Name != healthCheckMongoDB || got.Checks[0].Status != operations.HealthStatusFailed || got.Checks[0].Message != "server selection timeout" {
+		t.Errorf("GetReadiness(): want a failed database check, got %+v", got)
+	}
+}
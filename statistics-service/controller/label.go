@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"strings"
+)
+
+// getLabelKeys returns the configured label keys without empty entries
+func getLabelKeys(env config.EnvConfig) []string {
+	result := []string{}
+	for _, key := range env.LabelDimensions {
+		if key = strings.TrimSpace(key); key != "" {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// addLabels adds the counts of the event to the values of the promoted labels the event carries.
+// Values that exceed the cardinality limit of a label are counted as operations.OverflowKey
+func (sb *statisticsBucket) addLabels(event operations.Event, counts operations.LabelStatistics) {
+	for _, key := range sb.labelKeys {
+		value, ok := event.Data.Labels[key]
+		if !ok {
+			continue
+		}
+		if sb.cardinalityLimiter != nil {
+			dimension := operations.LabelGroupPrefix + key
+			if value, ok = sb.cardinalityLimiter.limit(dimension, value); !ok {
+				sb.handleOverflow(dimension)
+			}
+		}
+		sb.Statistics.IncreaseLabelCount(event.Data.Project, event.Data.Stage, event.Data.Service, key, value, counts)
+	}
+}
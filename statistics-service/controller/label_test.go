@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"github.com/go-test/deep"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+)
+
+func Test_statisticsBucket_addLabels(t *testing.T) {
+	env := config.EnvConfig{
+		LabelDimensions: []string{"team", " ", "buildId"},
+		MaxLabelValues:  2,
+	}
+	sb := &statisticsBucket{
+		logger:             keptn.NewLogger("", "", ""),
+		cardinalityLimiter: newCardinalityLimiter(env),
+		labelKeys:          getLabelKeys(env),
+	}
+	sb.createNewBucket()
+
+	newEvent := func(eventType string, labels map[string]string) operations.Event {
+		return operations.Event{
+			Type:        eventType,
+			Source:      "helm-service",
+			Specversion: "1.0",
+			Data: operations.KeptnBase{
+				Project: "my-project",
+				Service: "carts",
+				Labels:  labels,
+			},
+		}
+	}
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.triggered", map[string]string{"team": "a", "buildId": "1"}))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.started", map[string]string{"team": "a", "buildId": "2"}))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.started", map[string]string{"team": "b", "buildId": "3", "owner": "me"}))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.started", nil))
+
+	want := map[string]map[string]*operations.LabelStatistics{
+		"team": {
+			"a": {Events: 2, KeptnServiceExecutions: 1},
+			"b": {Events: 1, KeptnServiceExecutions: 1},
+		},
+		"buildId": {
+			"1":                    {Events: 1},
+			"2":                    {Events: 1, KeptnServiceExecutions: 1},
+			operations.OverflowKey: {Events: 1, KeptnServiceExecutions: 1},
+		},
+	}
+	got := sb.Statistics.Projects["my-project"].Services["carts"].Labels
+	if diff := deep.Equal(got, want); len(diff) > 0 {
+		t.Error("addLabels(): did not get expected label statistics")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+	if sb.Statistics.Overflows["label:buildId"] != 1 {
+		t.Errorf("addLabels(): want %d overflows, got %v", 1, sb.Statistics.Overflows)
+	}
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// initUsageMetrics creates the usage counters exposed at /metrics. If the configuration is invalid or the counters can not
+// be registered, an error is returned and no usage counters are set
+func (sb *statisticsBucket) initUsageMetrics(env config.EnvConfig) error {
+	usageMetrics, err := metrics.NewUsageMetrics(env.MetricsLabels, env.MetricsMaxSeries, prometheus.DefaultRegisterer)
+	if err != nil {
+		return err
+	}
+	sb.usageMetrics = usageMetrics
+	return nil
+}
+
+// updateBucketSize publishes the number of events, projects and services of the current bucket
+func (sb *statisticsBucket) updateBucketSize() {
+	metrics.SetBucketSize(sb.bucketEvents, len(sb.Statistics.Projects), sb.Statistics.CountServices())
+}
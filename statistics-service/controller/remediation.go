@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"strings"
+	"time"
+)
+
+const unknownActionType = "unknown"
+
+// trackRemediation counts the opened and closed problems, the triggered remediation sequences and the executed remediation actions.
+// Since action.finished events do not contain the type of the action, it is taken from the corresponding action.triggered event
+func (sb *statisticsBucket) trackRemediation(event operations.Event, now time.Time) {
+	switch {
+	case isProblemOpenEvent(event):
+		sb.Statistics.IncreaseProblemCount(event.Data.Project, event.Data.Stage, event.Data.Service, false, 1)
+	case isProblemClosedEvent(event):
+		sb.Statistics.IncreaseProblemCount(event.Data.Project, event.Data.Stage, event.Data.Service, true, 1)
+	case strings.HasSuffix(event.Type, ".remediation.triggered"):
+		sb.Statistics.IncreaseRemediationCount(event.Data.Project, event.Data.Stage, event.Data.Service, 1)
+	case event.Type == "sh.keptn.event.action.triggered":
+		actionType := getActionType(event)
+		sb.Statistics.IncreaseRemediationActionTriggeredCount(event.Data.Project, event.Data.Stage, event.Data.Service, actionType, 1)
+		if sb.correlator != nil && event.ID != "" {
+			sb.correlator.addWithValue("action/"+event.ID, now, actionType, now)
+		}
+	case event.Type == "sh.keptn.event.action.finished":
+		actionType := getActionType(event)
+		if sb.correlator != nil && event.Triggeredid != "" {
+			if _, triggeredActionType, ok := sb.correlator.resolveWithValue("action/"+event.Triggeredid, now); ok && actionType == unknownActionType {
+				actionType = triggeredActionType
+			}
+		}
+		result, status := event.Data.Result, event.Data.Status
+		if event.Data.Action != nil && (event.Data.Action.Result != "" || event.Data.Action.Status != "") {
+			result, status = event.Data.Action.Result, event.Data.Action.Status
+		}
+		succeeded := !isFailedResult(result, status)
+		sb.Statistics.IncreaseRemediationActionFinishedCount(event.Data.Project, event.Data.Stage, event.Data.Service, actionType, succeeded, 1)
+	}
+}
+
+func getActionType(event operations.Event) string {
+	if event.Data.Action == nil || event.Data.Action.Action == "" {
+		return unknownActionType
+	}
+	return event.Data.Action.Action
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"github.com/go-test/deep"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+	"time"
+)
+
+func Test_statisticsBucket_trackRemediation(t *testing.T) {
+	newEvent := func(eventType, id, triggeredID string, data operations.KeptnBase) operations.Event {
+		data.Project = "my-project"
+		data.Stage = "production"
+		data.Service = "my-service"
+		return operations.Event{
+			Type:           eventType,
+			Source:         "remediation-service",
+			ID:             id,
+			Triggeredid:    triggeredID,
+			Shkeptncontext: "my-context",
+			Data:           data,
+		}
+	}
+
+	sb := &statisticsBucket{
+		logger:     keptn.NewLogger("", "", ""),
+		correlator: newEventCorrelator(100, time.Hour),
+	}
+	sb.createNewBucket()
+
+	events := []operations.Event{
+		newEvent("sh.keptn.event.problem.open", "", "", operations.KeptnBase{ProblemID: "1"}),
+		newEvent("sh.keptn.event.production.remediation.triggered", "", "", operations.KeptnBase{}),
+		newEvent("sh.keptn.event.action.triggered", "action-1", "", operations.KeptnBase{Action: &operations.ActionDetails{Action: "scaling"}}),
+		newEvent("sh.keptn.event.action.finished", "", "action-1", operations.KeptnBase{Result: "fail"}),
+		newEvent("sh.keptn.event.action.triggered", "action-2", "", operations.KeptnBase{Action: &operations.ActionDetails{Action: "toggle-feature"}}),
+		newEvent("sh.keptn.event.action.finished", "", "action-2", operations.KeptnBase{Action: &operations.ActionDetails{Result: "pass", Status: "succeeded"}}),
+		newEvent("sh.keptn.event.action.finished", "", "", operations.KeptnBase{Result: "pass"}),
+		newEvent("sh.keptn.events.problem", "", "", operations.KeptnBase{State: "RESOLVED", ProblemID: "1"}),
+	}
+	for _, event := range events {
+		sb.AddEvent(event)
+	}
+
+	want := &operations.RemediationStatistics{
+		ProblemsOpened:        1,
+		ProblemsClosed:        1,
+		RemediationsTriggered: 1,
+		Actions: map[string]*operations.RemediationActionStatistics{
+			"scaling":        {Triggered: 1, Finished: 1, Succeeded: 0},
+			"toggle-feature": {Triggered: 1, Finished: 1, Succeeded: 1},
+			"unknown":        {Triggered: 0, Finished: 1, Succeeded: 1},
+		},
+	}
+
+	for name, service := range map[string]*operations.Service{
+		"project": sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"],
+		"stage":   sb.Statistics.Projects["my-project"].Stages["production"].Services["my-service"],
+	} {
+		if diff := deep.Equal(service.Remediation, want); len(diff) > 0 {
+			t.Errorf("trackRemediation(): did not get expected remediation statistics for %s", name)
+			for _, d := range diff {
+				t.Log(d)
+			}
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	keptn "github.com/keptn/go-utils/pkg/lib"
+	"log"
 	"strings"
 	"sync"
 	"time"
@@ -13,14 +15,44 @@ import (
 
 var statisticsBucketInstance *statisticsBucket
 
+const shipyardController = "shipyard-controller"
+
 type statisticsBucket struct {
-	StatisticsRepo  db.StatisticsRepo
-	Statistics      operations.Statistics
-	uniqueSequences map[string]bool
-	logger          keptn.LoggerInterface
-	lock            sync.Mutex
-	cutoffTime      time.Time
-	nextGenEvents   bool
+	StatisticsRepo db.StatisticsRepo
+	Statistics     operations.Statistics
+	logger         keptn.LoggerInterface
+	lock           sync.Mutex
+	correlator     *eventCorrelator
+	// contexts contains the Keptn contexts whose first event has been received. It is separate from the correlator, so the contexts do not evict pending correlations
+	contexts *eventCorrelator
+	// triggeredTasks contains the .triggered events of tasks. They are kept until they expire, since multiple Keptn services may respond to them, and are therefore separate from the correlator as well
+	triggeredTasks *eventCorrelator
+	// classificationRules decide which events are counted as Keptn service executions and executed sequences
+	classificationRules *classificationRules
+	// cardinalityLimiter caps the number of distinct names per bucket
+	cardinalityLimiter *cardinalityLimiter
+	// ingestionFilter decides which events are counted
+	ingestionFilter *ingestionFilter
+	// labelKeys contains the keys of the labels that are promoted to dimensions
+	labelKeys []string
+	// usageMetrics counts the events and executions since the start of the service
+	usageMetrics *metrics.UsageMetrics
+	// bucketEvents contains the number of events of the current bucket
+	bucketEvents int
+	// aggregationInterval is the time after which the current bucket is stored and a new one is created
+	aggregationInterval time.Duration
+	// maxBucketSize is the maximum size of a serialized bucket in bytes; 0 disables the limit
+	maxBucketSize int
+	// healthLock guards the start of the current bucket and the results of the last flush, so that health checks do not wait for a flush in progress
+	healthLock sync.Mutex
+	// cutoffTime contains the start of the current bucket
+	cutoffTime time.Time
+	// lastRotation contains the time the current bucket has been created or, if it could not be stored, been kept
+	lastRotation time.Time
+	// lastSuccessfulFlush contains the time the last bucket has been stored
+	lastSuccessfulFlush time.Time
+	// lastFlushError contains the error of the last attempt to store a bucket, or nil if it has been successful
+	lastFlushError error
 }
 
 // GetStatisticsBucketInstance godoc
@@ -28,14 +60,29 @@ func GetStatisticsBucketInstance() *statisticsBucket {
 	if statisticsBucketInstance == nil {
 		env := config.GetConfig()
 		statisticsBucketInstance = &statisticsBucket{
-			StatisticsRepo: &db.StatisticsMongoDBRepo{},
-			logger:         keptn.NewLogger("", "", "statistics service"),
-			nextGenEvents:  env.NextGenEvents,
+			StatisticsRepo:      &db.StatisticsMongoDBRepo{},
+			logger:              keptn.NewLogger("", "", "statistics service"),
+			correlator:          newEventCorrelator(env.MaxPendingCorrelations, time.Duration(env.CorrelationTimeoutSeconds)*time.Second),
+			contexts:            newEventCorrelator(env.MaxTrackedContexts, time.Duration(env.CorrelationTimeoutSeconds)*time.Second),
+			triggeredTasks:      newEventCorrelator(env.MaxTrackedTasks, time.Duration(env.CorrelationTimeoutSeconds)*time.Second),
+			cardinalityLimiter:  newCardinalityLimiter(env),
+			labelKeys:           getLabelKeys(env),
+			aggregationInterval: time.Duration(env.AggregationIntervalSeconds) * time.Second,
+			maxBucketSize:       env.MaxBucketSizeBytes,
 		}
 
+		if err := statisticsBucketInstance.initUsageMetrics(env); err != nil {
+			// running without the usage counters would silently drop the configured metrics
+			log.Fatalf("could not create usage metrics: %s", err.Error())
+		}
+		statisticsBucketInstance.initClassificationRules(env)
+		if err := statisticsBucketInstance.initIngestionFilter(env); err != nil {
+			// counting all events instead would silently ignore the configured filter
+			log.Fatalf("could not create ingestion filter: %s", err.Error())
+		}
 		statisticsBucketInstance.createNewBucket()
 		go func() {
-			bucketInterval := time.Duration(env.AggregationIntervalSeconds) * time.Second
+			bucketInterval := statisticsBucketInstance.aggregationInterval
 			bucketTimer := time.NewTimer(bucketInterval)
 			defer bucketTimer.Stop()
 			for {
@@ -52,6 +99,8 @@ func GetStatisticsBucketInstance() *statisticsBucket {
 
 // GetCutoffTime
 func (sb *statisticsBucket) GetCutoffTime() time.Time {
+	sb.healthLock.Lock()
+	defer sb.healthLock.Unlock()
 	return sb.cutoffTime
 }
 
@@ -71,37 +120,172 @@ func (sb *statisticsBucket) AddEvent(event operations.Event) {
 	defer sb.lock.Unlock()
 
 	if event.Data.Project == "" || event.Data.Service == "" || event.Type == "" || event.Source == "" {
+		metrics.EventsRejected.WithLabelValues(metrics.RejectionInvalidEvent).Inc()
 		return
 	}
-	sb.logger.Info("updating statistics for service " + event.Data.Service + " in project " + event.Data.Project)
-	sb.uniqueSequences[event.Shkeptncontext] = true
-
-	sb.Statistics.IncreaseEventTypeCount(event.Data.Project, event.Data.Service, event.Type, 1)
-
-	if sb.nextGenEvents {
-		// increase service execution count using .started events
-		if strings.HasSuffix(event.Type, ".started") {
-			sb.Statistics.IncreaseKeptnServiceExecutionCount(
-				event.Data.Project,
-				event.Data.Service,
-				event.Source,
-				strings.TrimSuffix(event.Type, ".started"), 1,
-			)
+	if event.Data.Stage == "" {
+		// the stage of sequence events of Keptn >= 0.8 can be derived from the event type
+		if keptnEventType, ok := operations.ParseEventType(event.Type); ok {
+			event.Data.Stage = keptnEventType.Stage
 		}
-		if strings.HasSuffix(event.Type, ".finished") && event.Source == "shipyard-controller" {
-			// when the shipyard controller sends a .finished event, this means that a task sequence has been completed
-			sb.Statistics.IncreaseExecutedSequencesCount(event.Data.Project, event.Data.Service, 1)
-			sb.Statistics.IncreaseExecutedSequenceCountForType(event.Data.Project, event.Data.Service, strings.TrimSuffix(event.Type, ".finished"), 1)
-		}
-	} else {
-		// increase service execution count using 'source' property from event
-		sb.Statistics.IncreaseKeptnServiceExecutionCount(
+	}
+	// the version is removed from the source before the filters and the cardinality limits are applied, so that source patterns match the Keptn service name,
+	// and the executions and the inventory use the same name
+	keptnServiceName, version := event.GetKeptnServiceVersion()
+	event.Source = keptnServiceName
+	if sb.isFiltered(event) {
+		metrics.EventsRejected.WithLabelValues(metrics.RejectionFiltered).Inc()
+		return
+	}
+	sb.applyCardinalityLimits(&event)
+	sb.logger.Info("updating statistics for service " + event.Data.Service + " in project " + event.Data.Project)
+	if event.Shkeptncontext != "" {
+		sb.Statistics.AddUniqueSequence(event.Data.Project, event.Data.Stage, event.Data.Service, event.Shkeptncontext)
+	}
+
+	sb.Statistics.IncreaseEventTypeCount(event.Data.Project, event.Data.Stage, event.Data.Service, event.Type, 1)
+	sb.usageMetrics.AddEvent(event.Data.Project, event.Data.Service, event.Source, event.Type)
+	sb.bucketEvents++
+	sb.updateBucketSize()
+
+	now := time.Now()
+	seen, err := event.GetTime()
+	if err != nil {
+		seen = now
+	}
+	sb.Statistics.AddKeptnServiceVersion(event.Data.Project, event.Source, version, seen)
+
+	// increase service execution and sequence counts as defined by the classification rules
+	executions, sequences := sb.getClassificationRules().apply(event, &sb.Statistics)
+	for _, taskType := range executions {
+		sb.usageMetrics.AddExecution(event.Data.Project, event.Data.Service, event.Source, taskType)
+	}
+	sb.addLabels(event, operations.LabelStatistics{Events: 1, KeptnServiceExecutions: len(executions), ExecutedSequences: sequences})
+
+	if isFinishedEvent(event.Type) && (event.Data.Result != "" || event.Data.Status != "") {
+		// use the logical task name as for the service executions, so the results can be related to them
+		sb.Statistics.IncreaseTaskResultCount(
 			event.Data.Project,
+			event.Data.Stage,
 			event.Data.Service,
 			event.Source,
-			event.Type, 1,
+			operations.GetTaskName(event.Type),
+			strings.ToLower(event.Data.Result),
+			strings.ToLower(event.Data.Status), 1,
 		)
 	}
+
+	if isEvaluationFinishedEvent(event.Type) {
+		sb.addEvaluation(event)
+	}
+
+	sb.trackRemediation(event, now)
+	sb.trackApprovals(event, now)
+
+	if sb.correlator != nil {
+		sb.trackDurations(event, now)
+		sb.trackDora(event, now)
+	}
+	if sb.contexts != nil {
+		sb.trackTriggers(event, now)
+	}
+}
+
+func isEvaluationFinishedEvent(eventType string) bool {
+	return eventType == "sh.keptn.event.evaluation.finished" || eventType == "sh.keptn.events.evaluation-done"
+}
+
+func (sb *statisticsBucket) addEvaluation(event operations.Event) {
+	details := event.Data.Evaluation
+	if details == nil {
+		// Keptn < 0.8 reports the evaluation result in the evaluationdetails property
+		details = event.Data.EvaluationDetails
+	}
+
+	result := event.Data.Result
+	score := 0.0
+	hasScore := false
+	if details != nil {
+		if details.Result != "" {
+			result = details.Result
+		}
+		if details.Score != nil {
+			score = *details.Score
+			hasScore = true
+		}
+	}
+	sb.Statistics.AddEvaluation(event.Data.Project, event.Data.Stage, event.Data.Service, result, score, hasScore)
+}
+
+// isFinishedEvent returns true for .finished events, as well as for their counterparts in Keptn < 0.8 (e.g. deployment-finished or evaluation-done)
+func isFinishedEvent(eventType string) bool {
+	return strings.HasSuffix(eventType, ".finished") || strings.HasSuffix(eventType, "-finished") || strings.HasSuffix(eventType, "-done")
+}
+
+// trackDurations correlates .triggered and .started events with their .finished events and records the duration of tasks and sequences
+func (sb *statisticsBucket) trackDurations(event operations.Event, now time.Time) {
+	eventTime, err := event.GetTime()
+	if err != nil {
+		eventTime = now
+	}
+
+	var taskType string
+	var triggeredKey string
+	switch {
+	case strings.HasSuffix(event.Type, ".triggered"):
+		taskType = operations.GetTaskName(event.Type)
+		if event.ID != "" {
+			triggeredKey = "triggered/" + event.ID
+		} else {
+			triggeredKey = "triggered/" + event.Shkeptncontext + "/" + event.Data.Stage + "/" + taskType
+		}
+		if keptnEventType, ok := operations.ParseEventType(event.Type); ok && !keptnEventType.IsSequenceEvent() {
+			if sb.triggeredTasks != nil {
+				sb.triggeredTasks.add(triggeredKey, eventTime, now)
+			}
+			return
+		}
+		sb.correlator.add(triggeredKey, eventTime, now)
+		return
+	case strings.HasSuffix(event.Type, ".started"):
+		taskType = operations.GetTaskName(event.Type)
+		sb.correlator.add(getStartedCorrelationKey(event, taskType), eventTime, now)
+		return
+	case strings.HasSuffix(event.Type, ".finished"):
+		taskType = operations.GetTaskName(event.Type)
+	default:
+		return
+	}
+
+	if event.Triggeredid != "" {
+		triggeredKey = "triggered/" + event.Triggeredid
+	} else {
+		triggeredKey = "triggered/" + event.Shkeptncontext + "/" + event.Data.Stage + "/" + taskType
+	}
+
+	if event.Source == shipyardController {
+		// the shipyard controller sends a .finished event when a sequence has been completed
+		if startTime, ok := sb.correlator.resolve(triggeredKey, now); ok {
+			sb.Statistics.AddSequenceDuration(event.Data.Project, event.Data.Stage, event.Data.Service, taskType, eventTime.Sub(startTime))
+		}
+		return
+	}
+
+	// multiple Keptn services may respond to the same .triggered event, so the .triggered event is only used if no .started event has been received
+	startTime, ok := sb.correlator.resolve(getStartedCorrelationKey(event, taskType), now)
+	if !ok && sb.triggeredTasks != nil {
+		startTime, ok = sb.triggeredTasks.get(triggeredKey, now)
+	}
+	if ok {
+		sb.Statistics.AddTaskDuration(event.Data.Project, event.Data.Stage, event.Data.Service, event.Source, taskType, eventTime.Sub(startTime))
+	}
+}
+
+func getStartedCorrelationKey(event operations.Event, taskType string) string {
+	if event.Triggeredid != "" {
+		return "started/" + event.Triggeredid + "/" + event.Source
+	}
+	return "started/" + event.Shkeptncontext + "/" + event.Data.Stage + "/" + taskType + "/" + event.Source
 }
 
 func (sb *statisticsBucket) storeCurrentBucket() {
@@ -109,18 +293,47 @@ func (sb *statisticsBucket) storeCurrentBucket() {
 	defer sb.lock.Unlock()
 	sb.logger.Info(fmt.Sprintf("Storing statistics for time frame %s - %s\n\n", sb.Statistics.From.String(), sb.Statistics.To.String()))
 	sb.Statistics.To = time.Now().Round(time.Second)
-	if err := sb.StatisticsRepo.StoreStatistics(sb.Statistics); err != nil {
+	sb.limitBucketSize()
+	start := time.Now()
+	err := sb.StatisticsRepo.StoreStatistics(sb.Statistics)
+	metrics.BucketFlushDuration.Observe(time.Since(start).Seconds())
+	sb.healthLock.Lock()
+	sb.lastFlushError = err
+	if err == nil {
+		sb.lastSuccessfulFlush = time.Now()
+	}
+	sb.healthLock.Unlock()
+	if err != nil {
+		metrics.BucketFlushFailures.Inc()
 		sb.logger.Error(fmt.Sprintf("Could not store statistics: " + err.Error()))
+		return
 	}
 	sb.logger.Info(fmt.Sprintf("Statistics stored successfully"))
 }
 
+// createNewBucket replaces the current bucket by an empty one. If the current bucket could not be stored, it is kept
+// instead, so that its events are stored together with the events of the next interval once the database is available again
 func (sb *statisticsBucket) createNewBucket() {
 	sb.lock.Lock()
 	defer sb.lock.Unlock()
-	sb.cutoffTime = time.Now().Round(time.Second)
-	sb.uniqueSequences = map[string]bool{}
+	cutoffTime := time.Now().Round(time.Second)
+	sb.healthLock.Lock()
+	sb.lastRotation = cutoffTime
+	keepBucket := sb.lastFlushError != nil && !sb.cutoffTime.IsZero()
+	if !keepBucket {
+		sb.cutoffTime = cutoffTime
+	}
+	sb.healthLock.Unlock()
+	if keepBucket {
+		sb.logger.Info(fmt.Sprintf("Keeping the statistics since %s, since they could not be stored", sb.Statistics.From.String()))
+		return
+	}
 	sb.Statistics = operations.Statistics{
-		From: time.Now().Round(time.Second),
+		From: cutoffTime,
+	}
+	if sb.cardinalityLimiter != nil {
+		sb.cardinalityLimiter.reset()
 	}
+	sb.bucketEvents = 0
+	metrics.SetBucketSize(0, 0, 0)
 }
@@ -1,13 +1,15 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"github.com/go-test/deep"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/config"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/db"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	keptn "github.com/keptn/go-utils/pkg/lib"
 	"os"
 	"strconv"
-	"sync"
 	"testing"
 	"time"
 )
@@ -20,6 +22,8 @@ type MockStatisticsRepo struct {
 	StoreStatisticsFunc func(statistics operations.Statistics) error
 	// DeleteStatisticsFunc godoc
 	DeleteStatisticsFunc func(from, to time.Time) error
+	// CheckConnectionFunc godoc
+	CheckConnectionFunc func() error
 }
 
 // GetStatistics godoc
@@ -37,14 +41,25 @@ func (m *MockStatisticsRepo) DeleteStatistics(from, to time.Time) error {
 	return m.DeleteStatisticsFunc(from, to)
 }
 
+// CheckConnection godoc
+func (m *MockStatisticsRepo) CheckConnection() error {
+	return m.CheckConnectionFunc()
+}
+
+func newUniqueSequences(keptnContexts ...string) *operations.HyperLogLog {
+	uniqueSequences := operations.NewHyperLogLog()
+	for _, keptnContext := range keptnContexts {
+		uniqueSequences.Add(keptnContext)
+	}
+	return uniqueSequences
+}
+
 func Test_statisticsBucket_createNewBucket(t *testing.T) {
 	type fields struct {
-		StatisticsRepo  db.StatisticsRepo
-		Statistics      operations.Statistics
-		uniqueSequences map[string]bool
-		logger          keptn.LoggerInterface
-		lock            sync.Mutex
-		cutoffTime      time.Time
+		StatisticsRepo db.StatisticsRepo
+		Statistics     operations.Statistics
+		logger         keptn.LoggerInterface
+		cutoffTime     time.Time
 	}
 	tests := []struct {
 		name   string
@@ -53,7 +68,6 @@ func Test_statisticsBucket_createNewBucket(t *testing.T) {
 		{
 			name: "create statistics bucket - initially nil",
 			fields: fields{
-				lock:       sync.Mutex{},
 				cutoffTime: time.Time{},
 			},
 		},
@@ -70,11 +84,7 @@ func Test_statisticsBucket_createNewBucket(t *testing.T) {
 						},
 					},
 				},
-				uniqueSequences: map[string]bool{
-					"test-context": true,
-				},
 				logger:     nil,
-				lock:       sync.Mutex{},
 				cutoffTime: time.Time{},
 			},
 		},
@@ -82,34 +92,27 @@ func Test_statisticsBucket_createNewBucket(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sb := &statisticsBucket{
-				StatisticsRepo:  tt.fields.StatisticsRepo,
-				Statistics:      tt.fields.Statistics,
-				uniqueSequences: tt.fields.uniqueSequences,
-				logger:          tt.fields.logger,
-				lock:            tt.fields.lock,
-				cutoffTime:      tt.fields.cutoffTime,
+				StatisticsRepo: tt.fields.StatisticsRepo,
+				Statistics:     tt.fields.Statistics,
+				logger:         tt.fields.logger,
+				cutoffTime:     tt.fields.cutoffTime,
 			}
 			sb.createNewBucket()
 
 			if len(sb.Statistics.Projects) > 0 {
 				t.Errorf("Statistics have not been replaced properly. Got length = %d", len(sb.Statistics.Projects))
 			}
-			if len(sb.uniqueSequences) > 0 {
-				t.Errorf("uniqueSequuences have not been replaced properly. Got length = %d", len(sb.uniqueSequences))
-			}
 		})
 	}
 }
 
 func Test_statisticsBucket_storeCurrentBucket(t *testing.T) {
 	type fields struct {
-		StatisticsRepo  *MockStatisticsRepo
-		Statistics      operations.Statistics
-		bucketTimer     *time.Ticker
-		uniqueSequences map[string]bool
-		logger          keptn.LoggerInterface
-		lock            sync.Mutex
-		cutoffTime      time.Time
+		StatisticsRepo *MockStatisticsRepo
+		Statistics     operations.Statistics
+		bucketTimer    *time.Ticker
+		logger         keptn.LoggerInterface
+		cutoffTime     time.Time
 	}
 	tests := []struct {
 		name   string
@@ -132,11 +135,9 @@ func Test_statisticsBucket_storeCurrentBucket(t *testing.T) {
 						},
 					},
 				},
-				bucketTimer:     nil,
-				uniqueSequences: nil,
-				logger:          keptn.NewLogger("", "", ""),
-				lock:            sync.Mutex{},
-				cutoffTime:      time.Time{},
+				bucketTimer: nil,
+				logger:      keptn.NewLogger("", "", ""),
+				cutoffTime:  time.Time{},
 			},
 		},
 	}
@@ -144,12 +145,10 @@ func Test_statisticsBucket_storeCurrentBucket(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			sb := &statisticsBucket{
-				StatisticsRepo:  tt.fields.StatisticsRepo,
-				Statistics:      tt.fields.Statistics,
-				uniqueSequences: tt.fields.uniqueSequences,
-				logger:          tt.fields.logger,
-				lock:            tt.fields.lock,
-				cutoffTime:      tt.fields.cutoffTime,
+				StatisticsRepo: tt.fields.StatisticsRepo,
+				Statistics:     tt.fields.Statistics,
+				logger:         tt.fields.logger,
+				cutoffTime:     tt.fields.cutoffTime,
 			}
 			tt.fields.StatisticsRepo.StoreStatisticsFunc = func(statistics operations.Statistics) error {
 				diff := deep.Equal(statistics, sb.Statistics)
@@ -167,15 +166,71 @@ func Test_statisticsBucket_storeCurrentBucket(t *testing.T) {
 	}
 }
 
+func Test_statisticsBucket_storeCurrentBucketKeepsFailedBucket(t *testing.T) {
+	var storeErr error
+	stored := []operations.Statistics{}
+	sb := &statisticsBucket{
+		StatisticsRepo: &MockStatisticsRepo{
+			StoreStatisticsFunc: func(statistics operations.Statistics) error {
+				if storeErr != nil {
+					return storeErr
+				}
+				stored = append(stored, statistics)
+				return nil
+			},
+		},
+		logger:              keptn.NewLogger("", "", ""),
+		aggregationInterval: 30 * time.Minute,
+	}
+	sb.createNewBucket()
+	bucketStart := sb.GetCutoffTime()
+	event := operations.Event{Type: "my-type", Source: "my-source", Data: operations.KeptnBase{Project: "sockshop", Service: "carts"}}
+
+	storeErr = errors.New("connection refused")
+	sb.AddEvent(event)
+	sb.storeCurrentBucket()
+	sb.createNewBucket()
+	if sb.bucketEvents != 1 || sb.Statistics.From != bucketStart || sb.GetCutoffTime() != bucketStart {
+		t.Errorf("createNewBucket(): want the bucket that could not be stored to be kept, got %d events since %s", sb.bucketEvents, sb.Statistics.From)
+	}
+	if got := sb.GetLiveness(time.Now()); !got.IsHealthy() {
+		t.Errorf("GetLiveness(): want a healthy service while the bucket is kept, got %+v", got)
+	}
+
+	storeErr = nil
+	sb.AddEvent(event)
+	sb.storeCurrentBucket()
+	sb.createNewBucket()
+	if len(stored) != 1 || stored[0].From != bucketStart || stored[0].Projects["sockshop"] == nil {
+		t.Fatalf("storeCurrentBucket(): want the kept bucket to be stored with the next flush, got %+v", stored)
+	}
+	if sb.bucketEvents != 0 || len(sb.Statistics.Projects) != 0 {
+		t.Errorf("createNewBucket(): want an empty bucket after a successful flush, got %d events", sb.bucketEvents)
+	}
+}
+
+func newKeptnServiceInventory(name string, events int, seen time.Time) *operations.KeptnServiceInventory {
+	return &operations.KeptnServiceInventory{
+		Name: name,
+		Versions: map[string]*operations.KeptnServiceVersion{
+			operations.UnknownVersion: {
+				Version:   operations.UnknownVersion,
+				Events:    events,
+				FirstSeen: seen,
+				LastSeen:  seen,
+			},
+		},
+	}
+}
+
 func Test_statisticsBucket_AddEvent(t *testing.T) {
+	eventTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
-		StatisticsRepo  db.StatisticsRepo
-		Statistics      operations.Statistics
-		bucketTimer     *time.Ticker
-		uniqueSequences map[string]bool
-		logger          keptn.LoggerInterface
-		lock            sync.Mutex
-		cutoffTime      time.Time
+		StatisticsRepo db.StatisticsRepo
+		Statistics     operations.Statistics
+		bucketTimer    *time.Ticker
+		logger         keptn.LoggerInterface
+		cutoffTime     time.Time
 	}
 	type args struct {
 		event operations.Event
@@ -185,7 +240,7 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 		fields                  fields
 		args                    args
 		expectedStatistics      operations.Statistics
-		expectedUniqueSequences map[string]bool
+		expectedUniqueSequences int
 	}{
 		{
 			name: "Add event to empty bucket",
@@ -196,11 +251,9 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 					To:       time.Time{},
 					Projects: nil,
 				},
-				bucketTimer:     nil,
-				uniqueSequences: map[string]bool{},
-				logger:          keptn.NewLogger("", "", ""),
-				lock:            sync.Mutex{},
-				cutoffTime:      time.Time{},
+				bucketTimer: nil,
+				logger:      keptn.NewLogger("", "", ""),
+				cutoffTime:  time.Time{},
 			},
 			args: args{
 				event: operations.Event{
@@ -211,14 +264,19 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 					Shkeptncontext: "my-context",
 					Type:           "my-type",
 					Source:         "my-keptn-service",
+					Time:           eventTime.Format(time.RFC3339Nano),
 				},
 			},
 			expectedStatistics: operations.Statistics{
-				From: time.Time{},
-				To:   time.Time{},
+				TaskNames: true,
+				From:      time.Time{},
+				To:        time.Time{},
 				Projects: map[string]*operations.Project{
 					"my-project": {
 						Name: "my-project",
+						KeptnServices: map[string]*operations.KeptnServiceInventory{
+							"my-keptn-service": newKeptnServiceInventory("my-keptn-service", 1, eventTime),
+						},
 						Services: map[string]*operations.Service{
 							"my-service": {
 								Name: "my-service",
@@ -234,14 +292,71 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 									},
 								},
 								ExecutedSequencesPerType: map[string]int{},
+								UniqueSequences:          newUniqueSequences("my-context"),
 							},
 						},
 					},
 				},
 			},
-			expectedUniqueSequences: map[string]bool{
-				"my-context": true,
+			expectedUniqueSequences: 1,
+		},
+		{
+			name: "Add event with stage to empty bucket",
+			fields: fields{
+				StatisticsRepo: nil,
+				Statistics:     operations.Statistics{},
+				logger:         keptn.NewLogger("", "", ""),
+			},
+			args: args{
+				event: operations.Event{
+					Data: operations.KeptnBase{
+						Project: "my-project",
+						Stage:   "dev",
+						Service: "my-service",
+					},
+					Shkeptncontext: "my-context",
+					Type:           "my-type",
+					Source:         "my-keptn-service",
+					Time:           eventTime.Format(time.RFC3339Nano),
+				},
+			},
+			expectedStatistics: operations.Statistics{
+				StagesOnly: true,
+				TaskNames:  true,
+				Projects: map[string]*operations.Project{
+					"my-project": {
+						Name: "my-project",
+						KeptnServices: map[string]*operations.KeptnServiceInventory{
+							"my-keptn-service": newKeptnServiceInventory("my-keptn-service", 1, eventTime),
+						},
+						Services: map[string]*operations.Service{},
+						Stages: map[string]*operations.Stage{
+							"dev": {
+								Name: "dev",
+								Services: map[string]*operations.Service{
+									"my-service": {
+										Name: "my-service",
+										Events: map[string]int{
+											"my-type": 1,
+										},
+										KeptnServiceExecutions: map[string]*operations.KeptnService{
+											"my-keptn-service": {
+												Name: "my-keptn-service",
+												Executions: map[string]int{
+													"my-type": 1,
+												},
+											},
+										},
+										ExecutedSequencesPerType: map[string]int{},
+										UniqueSequences:          newUniqueSequences("my-context"),
+									},
+								},
+							},
+						},
+					},
+				},
 			},
+			expectedUniqueSequences: 1,
 		},
 		{
 			name: "Add event to existing bucket",
@@ -273,11 +388,9 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 						},
 					},
 				},
-				bucketTimer:     nil,
-				uniqueSequences: map[string]bool{},
-				logger:          keptn.NewLogger("", "", ""),
-				lock:            sync.Mutex{},
-				cutoffTime:      time.Time{},
+				bucketTimer: nil,
+				logger:      keptn.NewLogger("", "", ""),
+				cutoffTime:  time.Time{},
 			},
 			args: args{
 				event: operations.Event{
@@ -288,14 +401,19 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 					Shkeptncontext: "my-context",
 					Type:           "my-type",
 					Source:         "my-keptn-service",
+					Time:           eventTime.Format(time.RFC3339Nano),
 				},
 			},
 			expectedStatistics: operations.Statistics{
-				From: time.Time{},
-				To:   time.Time{},
+				TaskNames: true,
+				From:      time.Time{},
+				To:        time.Time{},
 				Projects: map[string]*operations.Project{
 					"my-project": {
 						Name: "my-project",
+						KeptnServices: map[string]*operations.KeptnServiceInventory{
+							"my-keptn-service": newKeptnServiceInventory("my-keptn-service", 1, eventTime),
+						},
 						Services: map[string]*operations.Service{
 							"my-service": {
 								Name: "my-service",
@@ -311,14 +429,13 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 									},
 								},
 								ExecutedSequencesPerType: map[string]int{},
+								UniqueSequences:          newUniqueSequences("my-context"),
 							},
 						},
 					},
 				},
 			},
-			expectedUniqueSequences: map[string]bool{
-				"my-context": true,
-			},
+			expectedUniqueSequences: 1,
 		},
 		{
 			name: "Add event to existing bucket for second event of same context",
@@ -337,18 +454,15 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 										"my-type": 1,
 									},
 									ExecutedSequencesPerType: map[string]int{},
+									UniqueSequences:          newUniqueSequences("my-context"),
 								},
 							},
 						},
 					},
 				},
 				bucketTimer: nil,
-				uniqueSequences: map[string]bool{
-					"my-context": true,
-				},
-				logger:     keptn.NewLogger("", "", ""),
-				lock:       sync.Mutex{},
-				cutoffTime: time.Time{},
+				logger:      keptn.NewLogger("", "", ""),
+				cutoffTime:  time.Time{},
 			},
 			args: args{
 				event: operations.Event{
@@ -359,14 +473,19 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 					Shkeptncontext: "my-context",
 					Type:           "my-type",
 					Source:         "my-keptn-service",
+					Time:           eventTime.Format(time.RFC3339Nano),
 				},
 			},
 			expectedStatistics: operations.Statistics{
-				From: time.Time{},
-				To:   time.Time{},
+				TaskNames: true,
+				From:      time.Time{},
+				To:        time.Time{},
 				Projects: map[string]*operations.Project{
 					"my-project": {
 						Name: "my-project",
+						KeptnServices: map[string]*operations.KeptnServiceInventory{
+							"my-keptn-service": newKeptnServiceInventory("my-keptn-service", 1, eventTime),
+						},
 						Services: map[string]*operations.Service{
 							"my-service": {
 								Name: "my-service",
@@ -382,25 +501,22 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 									},
 								},
 								ExecutedSequencesPerType: map[string]int{},
+								UniqueSequences:          newUniqueSequences("my-context"),
 							},
 						},
 					},
 				},
 			},
-			expectedUniqueSequences: map[string]bool{
-				"my-context": true,
-			},
+			expectedUniqueSequences: 1,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sb := &statisticsBucket{
-				StatisticsRepo:  tt.fields.StatisticsRepo,
-				Statistics:      tt.fields.Statistics,
-				uniqueSequences: tt.fields.uniqueSequences,
-				logger:          tt.fields.logger,
-				lock:            tt.fields.lock,
-				cutoffTime:      tt.fields.cutoffTime,
+				StatisticsRepo: tt.fields.StatisticsRepo,
+				Statistics:     tt.fields.Statistics,
+				logger:         tt.fields.logger,
+				cutoffTime:     tt.fields.cutoffTime,
 			}
 
 			sb.AddEvent(tt.args.event)
@@ -413,12 +529,9 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 				}
 			}
 
-			diffUniqueSequences := deep.Equal(sb.uniqueSequences, tt.expectedUniqueSequences)
-			if len(diffUniqueSequences) > 0 {
-				t.Error("AddEvent() failed: did not get expected uniqueSequences")
-				for _, diff := range diffUniqueSequences {
-					t.Log(diff)
-				}
+			service := sb.Statistics.WithProjectTotals().Projects[tt.args.event.Data.Project].Services[tt.args.event.Data.Service]
+			if uniqueSequences := service.UniqueSequences.Count(); uniqueSequences != tt.expectedUniqueSequences {
+				t.Errorf("AddEvent() failed: did not get expected uniqueSequences. Got %d, want %d", uniqueSequences, tt.expectedUniqueSequences)
 			}
 		})
 	}
@@ -427,13 +540,19 @@ func Test_statisticsBucket_AddEvent(t *testing.T) {
 func TestStatisticsBucket(t *testing.T) {
 	interval := 5
 	os.Setenv("AGGREGATION_INTERVAL_SECONDS", strconv.FormatInt(int64(interval), 10))
+	eventTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
 
 	expectedStatistics := &operations.Statistics{
-		From: time.Time{},
-		To:   time.Time{},
+		From:      time.Time{},
+		To:        time.Time{},
+		TaskNames: true,
 		Projects: map[string]*operations.Project{
 			"my-project": {
 				Name: "my-project",
+				KeptnServices: map[string]*operations.KeptnServiceInventory{
+					"my-keptn-service":   newKeptnServiceInventory("my-keptn-service", 2, eventTime),
+					"my-keptn-service-2": newKeptnServiceInventory("my-keptn-service-2", 1, eventTime),
+				},
 				Services: map[string]*operations.Service{
 					"my-service": {
 						Name: "my-service",
@@ -457,6 +576,13 @@ func TestStatisticsBucket(t *testing.T) {
 							},
 						},
 						ExecutedSequencesPerType: map[string]int{},
+						UniqueSequences:          newUniqueSequences("my-context", "my-context-2"),
+						Triggers: map[string]map[string]int{
+							"my-type": {
+								"my-keptn-service":   1,
+								"my-keptn-service-2": 1,
+							},
+						},
 					},
 				},
 			},
@@ -508,6 +634,7 @@ func TestStatisticsBucket(t *testing.T) {
 		Shkeptncontext: "my-context",
 		Type:           "my-type",
 		Source:         "my-keptn-service",
+		Time:           eventTime.Format(time.RFC3339Nano),
 	})
 
 	sb.AddEvent(operations.Event{
@@ -518,6 +645,7 @@ func TestStatisticsBucket(t *testing.T) {
 		Shkeptncontext: "my-context",
 		Type:           "my-type-2",
 		Source:         "my-keptn-service",
+		Time:           eventTime.Format(time.RFC3339Nano),
 	})
 
 	sb.AddEvent(operations.Event{
@@ -528,6 +656,7 @@ func TestStatisticsBucket(t *testing.T) {
 		Shkeptncontext: "my-context-2",
 		Type:           "my-type",
 		Source:         "my-keptn-service-2",
+		Time:           eventTime.Format(time.RFC3339Nano),
 	})
 
 	select {
@@ -538,3 +667,282 @@ func TestStatisticsBucket(t *testing.T) {
 		break
 	}
 }
+
+func Test_statisticsBucket_trackDurations(t *testing.T) {
+	sb := &statisticsBucket{
+		logger:         keptn.NewLogger("", "", ""),
+		correlator:     newEventCorrelator(100, time.Hour),
+		triggeredTasks: newEventCorrelator(100, time.Hour),
+	}
+	sb.createNewBucket()
+
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	newEvent := func(eventType, source, id, triggeredID string, offset time.Duration) operations.Event {
+		return operations.Event{
+			Data: operations.KeptnBase{
+				Project: "my-project",
+				Stage:   "dev",
+				Service: "my-service",
+			},
+			ID:             id,
+			Shkeptncontext: "my-context",
+			Source:         source,
+			Time:           start.Add(offset).Format(time.RFC3339Nano),
+			Triggeredid:    triggeredID,
+			Type:           eventType,
+		}
+	}
+
+	sb.AddEvent(newEvent("sh.keptn.event.dev.delivery.triggered", "api", "sequence-id", "", 0))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.triggered", "shipyard-controller", "task-id", "", time.Second))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.started", "helm-service", "", "task-id", 5*time.Second))
+	sb.AddEvent(newEvent("sh.keptn.event.deployment.finished", "helm-service", "", "task-id", 65*time.Second))
+	sb.AddEvent(newEvent("sh.keptn.event.dev.delivery.finished", "shipyard-controller", "", "sequence-id", 100*time.Second))
+
+	service := sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"]
+
+	taskDuration := service.KeptnServiceExecutions["helm-service"].Durations["deployment"]
+	if taskDuration == nil || taskDuration.Count != 1 || taskDuration.Sum != 60 {
+		t.Errorf("trackDurations(): did not get expected task duration: %v", taskDuration)
+	}
+
+	sequenceDuration := service.SequenceDurations["delivery"]
+	if sequenceDuration == nil || sequenceDuration.Count != 1 || sequenceDuration.Sum != 100 {
+		t.Errorf("trackDurations(): did not get expected sequence duration: %v", sequenceDuration)
+	}
+
+	stageService := sb.Statistics.Projects["my-project"].Stages["dev"].Services["my-service"]
+	if stageService.SequenceDurations["delivery"] == nil {
+		t.Error("trackDurations(): sequence duration has not been recorded for stage")
+	}
+
+	// the start of the change and the deployment that awaits its evaluation are tracked for the DORA metrics
+	if sb.correlator.size() != 2 {
+		t.Errorf("trackDurations(): expected %d pending correlations, got %d", 2, sb.correlator.size())
+	}
+	// the .triggered event of the task is kept until it expires since other Keptn services may still respond to it
+	if sb.triggeredTasks.size() != 1 {
+		t.Errorf("trackDurations(): expected %d triggered task, got %d", 1, sb.triggeredTasks.size())
+	}
+}
+
+func Test_statisticsBucket_trackDurationsKeepsPendingSequences(t *testing.T) {
+	sb := &statisticsBucket{
+		logger:         keptn.NewLogger("", "", ""),
+		correlator:     newEventCorrelator(1, time.Hour),
+		triggeredTasks: newEventCorrelator(2, time.Hour),
+	}
+	sb.createNewBucket()
+
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	newEvent := func(eventType, source, id, triggeredID string, offset time.Duration) operations.Event {
+		return operations.Event{
+			Data:           operations.KeptnBase{Project: "my-project", Stage: "dev", Service: "my-service"},
+			ID:             id,
+			Shkeptncontext: "my-context",
+			Source:         source,
+			Time:           start.Add(offset).Format(time.RFC3339Nano),
+			Triggeredid:    triggeredID,
+			Type:           eventType,
+		}
+	}
+
+	sb.AddEvent(newEvent("sh.keptn.event.dev.evaluation.triggered", "api", "sequence-id", "", 0))
+	for i := 0; i < 5; i++ {
+		sb.AddEvent(newEvent("sh.keptn.event.evaluation.triggered", "shipyard-controller", fmt.Sprintf("task-id-%d", i), "", time.Second))
+	}
+	sb.AddEvent(newEvent("sh.keptn.event.evaluation.finished", "lighthouse-service", "", "task-id-4", 11*time.Second))
+	sb.AddEvent(newEvent("sh.keptn.event.dev.evaluation.finished", "shipyard-controller", "", "sequence-id", 20*time.Second))
+
+	service := sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"]
+	if duration := service.SequenceDurations["evaluation"]; duration == nil || duration.Sum != 20 {
+		t.Errorf("trackDurations(): the pending sequence has been evicted by the triggered tasks: %v", duration)
+	}
+	if duration := service.KeptnServiceExecutions["lighthouse-service"].Durations["evaluation"]; duration == nil || duration.Sum != 10 {
+		t.Errorf("trackDurations(): did not get expected task duration: %v", duration)
+	}
+	if got := sb.triggeredTasks.size(); got != 2 {
+		t.Errorf("trackDurations(): want %d triggered tasks, got %d", 2, got)
+	}
+}
+
+func Test_statisticsBucket_AddFinishedEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        operations.Event
+		wantTaskType string
+		want         *operations.TaskResults
+	}{
+		{
+			name: "next-gen finished event",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Service: "my-service",
+					Result:  "Fail",
+					Status:  "errored",
+				},
+				Type:   "sh.keptn.event.deployment.finished",
+				Source: "helm-service",
+			},
+			wantTaskType: "deployment",
+			want: &operations.TaskResults{
+				Results:  map[string]int{"fail": 1},
+				Statuses: map[string]int{"errored": 1},
+			},
+		},
+		{
+			name: "legacy finished event",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Service: "my-service",
+					Result:  "pass",
+				},
+				Type:   "sh.keptn.events.evaluation-done",
+				Source: "lighthouse-service",
+			},
+			wantTaskType: "evaluation",
+			want: &operations.TaskResults{
+				Results:  map[string]int{"pass": 1},
+				Statuses: map[string]int{},
+			},
+		},
+		{
+			name: "triggered event with result",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Service: "my-service",
+					Result:  "pass",
+				},
+				Type:   "sh.keptn.event.release.triggered",
+				Source: "shipyard-controller",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &statisticsBucket{
+				logger: keptn.NewLogger("", "", ""),
+			}
+			sb.createNewBucket()
+
+			sb.AddEvent(tt.event)
+
+			var got *operations.TaskResults
+			if keptnService := sb.Statistics.Projects["my-project"].Services["my-service"].KeptnServiceExecutions[tt.event.Source]; keptnService != nil {
+				got = keptnService.TaskResults[tt.wantTaskType]
+			}
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Error("AddEvent(): did not get expected task results")
+				for _, d := range diff {
+					t.Log(d)
+				}
+			}
+		})
+	}
+}
+
+func Test_statisticsBucket_AddEvaluationEvent(t *testing.T) {
+	score := 85.5
+	tests := []struct {
+		name  string
+		event operations.Event
+		want  *operations.EvaluationStatistics
+	}{
+		{
+			name: "next-gen evaluation.finished event",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Stage:   "hardening",
+					Service: "my-service",
+					Result:  "pass",
+					Evaluation: &operations.EvaluationDetails{
+						Result: "warning",
+						Score:  &score,
+					},
+				},
+				Type:   "sh.keptn.event.evaluation.finished",
+				Source: "lighthouse-service",
+			},
+			want: &operations.EvaluationStatistics{
+				Count:             1,
+				Results:           map[string]int{"warning": 1},
+				ScoreCount:        1,
+				ScoreSum:          85.5,
+				ScoreDistribution: []int{0, 0, 0, 0, 0, 0, 0, 0, 1, 0},
+			},
+		},
+		{
+			name: "legacy evaluation-done event",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Stage:   "hardening",
+					Service: "my-service",
+					EvaluationDetails: &operations.EvaluationDetails{
+						Result: "fail",
+						Score:  &score,
+					},
+				},
+				Type:   "sh.keptn.events.evaluation-done",
+				Source: "lighthouse-service",
+			},
+			want: &operations.EvaluationStatistics{
+				Count:             1,
+				Results:           map[string]int{"fail": 1},
+				ScoreCount:        1,
+				ScoreSum:          85.5,
+				ScoreDistribution: []int{0, 0, 0, 0, 0, 0, 0, 0, 1, 0},
+			},
+		},
+		{
+			name: "evaluation.finished event without score",
+			event: operations.Event{
+				Data: operations.KeptnBase{
+					Project: "my-project",
+					Stage:   "hardening",
+					Service: "my-service",
+					Result:  "pass",
+				},
+				Type:   "sh.keptn.event.evaluation.finished",
+				Source: "lighthouse-service",
+			},
+			want: &operations.EvaluationStatistics{
+				Count:             1,
+				Results:           map[string]int{"pass": 1},
+				ScoreDistribution: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &statisticsBucket{
+				logger: keptn.NewLogger("", "", ""),
+			}
+			sb.createNewBucket()
+
+			sb.AddEvent(tt.event)
+
+			got := sb.Statistics.Projects["my-project"].Stages["hardening"].Services["my-service"].Evaluations
+			if diff := deep.Equal(got, tt.want); len(diff) > 0 {
+				t.Error("AddEvent(): did not get expected evaluation statistics")
+				for _, d := range diff {
+					t.Log(d)
+				}
+			}
+		})
+	}
+}
+
+func Test_statisticsBucket_initUsageMetrics_Invalid(t *testing.T) {
+	sb := &statisticsBucket{
+		logger: keptn.NewLogger("", "", ""),
+	}
+	if err := sb.initUsageMetrics(config.EnvConfig{MetricsLabels: []string{"project", "unknown"}}); err == nil || sb.usageMetrics != nil {
+		t.Error("initUsageMetrics(): expected an error and no usage metrics for an unknown label")
+	}
+}
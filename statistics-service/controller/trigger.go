@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"time"
+)
+
+// trackTriggers counts how the sequence of a Keptn context has been started, based on the first event of the context.
+// Contexts are remembered until the correlation timeout expires or the limit of tracked contexts is reached, so a sequence may be counted twice if it runs longer
+func (sb *statisticsBucket) trackTriggers(event operations.Event, now time.Time) {
+	if event.Shkeptncontext == "" {
+		return
+	}
+	if _, ok := sb.contexts.get(event.Shkeptncontext, now); ok {
+		return
+	}
+	sb.contexts.add(event.Shkeptncontext, now, now)
+
+	// if the service has been started while a sequence was already running, the first received event is not the triggering one
+	if !isTriggeringEvent(event.Type) {
+		return
+	}
+	sb.Statistics.IncreaseTriggerCount(event.Data.Project, event.Data.Stage, event.Data.Service, operations.GetTaskName(event.Type), event.Source, 1)
+}
+
+// isTriggeringEvent returns true for events that can start a sequence. For Keptn >= 0.8, these are the .triggered events of sequences.
+// Events of Keptn < 0.8 do not distinguish between sequences and tasks, so all of them are accepted
+func isTriggeringEvent(eventType string) bool {
+	keptnEventType, ok := operations.ParseEventType(eventType)
+	if !ok {
+		return true
+	}
+	return keptnEventType.IsSequenceEvent() && keptnEventType.Phase == "triggered"
+}
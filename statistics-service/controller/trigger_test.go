@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"github.com/go-test/deep"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"testing"
+	"time"
+)
+
+func Test_statisticsBucket_trackTriggers(t *testing.T) {
+	newEvent := func(eventType, source, keptnContext string) operations.Event {
+		return operations.Event{
+			Type:           eventType,
+			Source:         source,
+			Shkeptncontext: keptnContext,
+			Data: operations.KeptnBase{
+				Project: "my-project",
+				Stage:   "dev",
+				Service: "my-service",
+			},
+		}
+	}
+
+	sb := &statisticsBucket{
+		logger:     keptn.NewLogger("", "", ""),
+		correlator: newEventCorrelator(100, time.Hour),
+		contexts:   newEventCorrelator(100, time.Hour),
+	}
+	sb.createNewBucket()
+
+	events := []operations.Event{
+		// delivery triggered via the CLI, followed by events of the same context
+		newEvent("sh.keptn.event.dev.delivery.triggered", "https://github.com/keptn/keptn/cli", "context-1"),
+		newEvent("sh.keptn.event.deployment.triggered", "shipyard-controller", "context-1"),
+		newEvent("sh.keptn.event.hardening.delivery.triggered", "shipyard-controller", "context-1"),
+		// evaluation triggered via the bridge
+		newEvent("sh.keptn.event.dev.evaluation.triggered", "bridge", "context-2"),
+		// problem sent by a monitoring integration (Keptn < 0.8)
+		newEvent("sh.keptn.event.problem.open", "dynatrace-service", "context-3"),
+		// the first received event of a sequence that has been started before is not counted
+		newEvent("sh.keptn.event.test.finished", "jmeter-service", "context-4"),
+		newEvent("sh.keptn.event.dev.delivery.triggered", "https://github.com/keptn/keptn/api", "context-4"),
+	}
+	for _, event := range events {
+		sb.AddEvent(event)
+	}
+
+	want := map[string]map[string]int{
+		"delivery": {
+			"https://github.com/keptn/keptn/cli": 1,
+		},
+		"evaluation": {
+			"bridge": 1,
+		},
+		"problem": {
+			"dynatrace-service": 1,
+		},
+	}
+	got := sb.Statistics.WithProjectTotals().Projects["my-project"].Services["my-service"].Triggers
+	if diff := deep.Equal(got, want); len(diff) > 0 {
+		t.Error("trackTriggers(): did not get expected triggers")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+}
+
+func Test_statisticsBucket_trackTriggersKeepsPendingCorrelations(t *testing.T) {
+	sb := &statisticsBucket{
+		logger:     keptn.NewLogger("", "", ""),
+		correlator: newEventCorrelator(1, time.Hour),
+		contexts:   newEventCorrelator(100, time.Hour),
+	}
+	sb.createNewBucket()
+
+	data := operations.KeptnBase{Project: "my-project", Stage: "dev", Service: "my-service"}
+	sb.AddEvent(operations.Event{Type: "sh.keptn.event.dev.evaluation.triggered", Source: "api", ID: "sequence-id", Shkeptncontext: "context-1", Data: data})
+	for _, keptnContext := range []string{"context-2", "context-3", "context-4"} {
+		sb.AddEvent(operations.Event{Type: "sh.keptn.event.test.finished", Source: "jmeter-service", Shkeptncontext: keptnContext, Data: data})
+	}
+
+	if _, ok := sb.correlator.get("triggered/sequence-id", time.Now()); !ok {
+		t.Error("trackTriggers(): the pending correlation has been evicted by the tracked contexts")
+	}
+	if got := sb.contexts.size(); got != 4 {
+		t.Errorf("trackTriggers(): want %d tracked contexts, got %d", 4, got)
+	}
+}
@@ -41,6 +41,13 @@ func (m *MongoDBConnection) EnsureDBConnection() error {
 	return nil
 }
 
+// getClient returns the current client, which is replaced by EnsureDBConnection when reconnecting
+func (m *MongoDBConnection) getClient() *mongo.Client {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return m.Client
+}
+
 func (m *MongoDBConnection) connectMongoDBClient() error {
 	var err error
 	m.Client, err = mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
 	"github.com/globalsign/mgo/bson"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/metrics"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
 	"go.mongodb.org/mongo-driver/mongo"
 	"time"
@@ -10,6 +12,9 @@ import (
 
 const keptnStatsCollection = "keptn-stats"
 
+// checkConnectionTimeout is below the timeout of the readiness probe of the deployment
+const checkConnectionTimeout = 5 * time.Second
+
 type StatisticsMongoDBRepo struct {
 	DbConnection    MongoDBConnection
 	statsCollection *mongo.Collection
@@ -24,6 +29,7 @@ func (s *StatisticsMongoDBRepo) GetStatistics(from, to time.Time) ([]operations.
 
 	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
 	defer cancel()
+	defer metrics.ObserveDatabaseOperation("find", time.Now())
 
 	searchOptions := bson.M{}
 
@@ -66,6 +72,7 @@ func (s *StatisticsMongoDBRepo) StoreStatistics(statistics operations.Statistics
 
 	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
 	defer cancel()
+	defer metrics.ObserveDatabaseOperation("insert", time.Now())
 
 	_, err = s.statsCollection.InsertOne(ctx, statistics)
 	if err != nil {
@@ -84,6 +91,7 @@ func (s *StatisticsMongoDBRepo) DeleteStatistics(from, to time.Time) error {
 
 	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
 	defer cancel()
+	defer metrics.ObserveDatabaseOperation("delete", time.Now())
 	searchOptions := bson.M{}
 
 	searchOptions["from"] = bson.M{
@@ -97,6 +105,25 @@ func (s *StatisticsMongoDBRepo) DeleteStatistics(from, to time.Time) error {
 	return err
 }
 
+// CheckConnection pings the database once. Unlike EnsureDBConnection, it does not reconnect a client that lost its
+// connection, so that it responds within the timeout of the readiness probe
+func (s *StatisticsMongoDBRepo) CheckConnection() error {
+	client := s.DbConnection.getClient()
+	if client == nil {
+		// creating a client does not wait for the database, which is verified by the ping below
+		if err := s.DbConnection.EnsureDBConnection(); err != nil {
+			return err
+		}
+		if client = s.DbConnection.getClient(); client == nil {
+			return errors.New("no MongoDB client has been initialized")
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), checkConnectionTimeout)
+	defer cancel()
+	defer metrics.ObserveDatabaseOperation("ping", time.Now())
+	return client.Ping(ctx, nil)
+}
+
 func (s *StatisticsMongoDBRepo) getCollection() error {
 	err := s.DbConnection.EnsureDBConnection()
 	if err != nil {
@@ -17,4 +17,6 @@ type StatisticsRepo interface {
 	StoreStatistics(statistics operations.Statistics) error
 	// DeleteStatistics godoc
 	DeleteStatistics(from, to time.Time) error
+	// CheckConnection returns an error if the database can not be reached
+	CheckConnection() error
 }
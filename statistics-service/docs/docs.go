@@ -31,6 +31,162 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/dora": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the deployment frequency, lead time for changes, change failure rate and time to restore per project, stage and service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get DORA metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include metrics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetDoraResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/evaluations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get statistics about the quality gate evaluations per project, stage and service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get evaluation statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include evaluations of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetEvaluationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/event": {
             "post": {
                 "security": [
@@ -79,6 +235,78 @@ var doc = `{
                 }
             }
         },
+        "/services": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the Keptn services and their versions that have sent events per project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get Keptn service inventory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetKeptnServicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/statistics": {
             "get": {
                 "security": [
@@ -100,15 +328,69 @@ var doc = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "From",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "To",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
                         "name": "to",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include statistics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return the totals per value of a label, e.g. label:team",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of projects, may contain '*' as a wildcard",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of services, may contain '*' as a wildcard",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of Keptn services, may contain '*' as a wildcard",
+                        "name": "keptnService",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types or task names, may contain '*' as a wildcard",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -132,102 +414,1764 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "operations.Error": {
-            "type": "object",
-            "properties": {
-                "errorCode": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
         },
-        "operations.Event": {
-            "type": "object",
-            "properties": {
-                "contenttype": {
-                    "type": "string"
-                },
-                "data": {
-                    "type": "object"
-                },
-                "extensions": {
-                    "type": "object"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "shkeptncontext": {
-                    "type": "string"
-                },
-                "source": {
+        "/statistics/compare": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the counts of two time frames and their delta for every project, service, Keptn service and event type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Compare statistics of two time frames",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the baseline time frame (default: time frame of the same length before 'from')",
+                        "name": "baselineFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the baseline time frame",
+                        "name": "baselineTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period of the baseline time frame, e.g. lastMonth",
+                        "name": "baselinePeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include statistics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of projects, may contain '*' as a wildcard",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of services, may contain '*' as a wildcard",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of Keptn services, may contain '*' as a wildcard",
+                        "name": "keptnService",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types or task names, may contain '*' as a wildcard",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count (absolute delta), optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetCompareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/summary": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the automation units, i.e. the number of executions of the included Keptn services and event types, overall, per project or per service. The CLI uses the same computation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get automation unit summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Level of the totals: overall (default), project or service",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types that define an automation unit (default: all)",
+                        "name": "includeEvents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of Keptn services that define an automation unit (default: all)",
+                        "name": "includeServices",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of projects that are not included",
+                        "name": "excludeProjects",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include statistics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of all lists: name or count, optionally followed by :asc or :desc (default: name:asc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/timeseries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get one data point per step for the selected counters. Steps without statistics have a value of zero",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get statistics as time series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Length of a data point: 1h, 1d, 1w or 1M",
+                        "name": "step",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of counters: events, keptnServiceExecutions, executedSequences, uniqueSequences",
+                        "name": "counters",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include statistics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of projects, may contain '*' as a wildcard",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of services, may contain '*' as a wildcard",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of Keptn services, may contain '*' as a wildcard",
+                        "name": "keptnService",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types or task names, may contain '*' as a wildcard",
+                        "name": "eventType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetTimeseriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/top": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the projects, services, Keptn services or event types with the highest number of executions, events or sequences and their share of the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get the most active entities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ranked entities: project, service, keptnService or eventType",
+                        "name": "by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking metric: executions, events or sequences (default: executions)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default: 10)",
+                        "name": "n",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From: RFC3339, Unix seconds or milliseconds, or relative, e.g. now-7d or startOfMonth",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To: RFC3339, Unix seconds or milliseconds, or relative, e.g. now (default if only from is set)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar period instead of from and to: today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear or lastYear",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for relative expressions and periods, e.g. Europe/Vienna (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include statistics of the given stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of projects, may contain '*' as a wildcard",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of services, may contain '*' as a wildcard",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of Keptn services, may contain '*' as a wildcard",
+                        "name": "keptnService",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types or task names, may contain '*' as a wildcard",
+                        "name": "eventType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/operations.GetTopResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "No statistics found",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/operations.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "operations.ActionDetails": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.ApprovalDetails": {
+            "type": "object",
+            "properties": {
+                "pass": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.ApprovalStatistics": {
+            "type": "object",
+            "properties": {
+                "approved": {
+                    "description": "Approved godoc",
+                    "type": "integer"
+                },
+                "automatic": {
+                    "description": "Automatic contains the number of approvals that have been finished automatically by the gatekeeper",
+                    "type": "integer"
+                },
+                "manual": {
+                    "description": "Manual contains the number of approvals that have been finished by a user",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "Rejected godoc",
+                    "type": "integer"
+                },
+                "requested": {
+                    "description": "Requested contains the number of approvals that have been triggered",
+                    "type": "integer"
+                },
+                "timesToApprove": {
+                    "description": "TimesToApprove contains the durations between the triggering and the finishing of approvals",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.DurationStatistics"
+                }
+            }
+        },
+        "operations.DoraStatistics": {
+            "type": "object",
+            "properties": {
+                "deployments": {
+                    "description": "Deployments contains the number of finished deployments",
+                    "type": "integer"
+                },
+                "failedDeployments": {
+                    "description": "FailedDeployments contains the number of deployments that have failed or whose subsequent evaluation has failed",
+                    "type": "integer"
+                },
+                "incidents": {
+                    "description": "Incidents contains the number of problems that have been opened",
+                    "type": "integer"
+                },
+                "leadTimes": {
+                    "description": "LeadTimes contains the durations between a configuration change and the successful deployment of the change",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.DurationStatistics"
+                },
+                "timesToRestore": {
+                    "description": "TimesToRestore contains the durations between the opening and the closing of a problem",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.DurationStatistics"
+                }
+            }
+        },
+        "operations.DurationStatistics": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "histogram": {
+                    "description": "Histogram contains the number of durations per bucket defined by DurationHistogramBounds",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max": {
+                    "description": "Max godoc",
+                    "type": "number"
+                },
+                "min": {
+                    "description": "Min godoc",
+                    "type": "number"
+                },
+                "sum": {
+                    "description": "Sum godoc",
+                    "type": "number"
+                }
+            }
+        },
+        "operations.Error": {
+            "type": "object",
+            "properties": {
+                "errorCode": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.EvaluationDetails": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "operations.EvaluationStatistics": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Results contains the number of evaluations per result (pass, warning, fail)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "scoreCount": {
+                    "description": "ScoreCount contains the number of evaluations that reported a score",
+                    "type": "integer"
+                },
+                "scoreDistribution": {
+                    "description": "ScoreDistribution contains the number of evaluations per score range (0-10, 10-20, ..., 90-100)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scoreSum": {
+                    "description": "ScoreSum godoc",
+                    "type": "number"
+                }
+            }
+        },
+        "operations.Event": {
+            "type": "object",
+            "properties": {
+                "contenttype": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/operations.KeptnBase"
+                },
+                "extensions": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "shkeptncontext": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "specversion": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "triggeredid": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetCompareResponse": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "description": "Baseline godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseTimeFrame"
+                },
+                "current": {
+                    "description": "Current godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseTimeFrame"
+                },
+                "events": {
+                    "description": "Events contains the number of events of all projects",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseDelta"
+                },
+                "projects": {
+                    "description": "Projects godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetCompareResponseProject"
+                    }
+                }
+            }
+        },
+        "operations.GetCompareResponseDelta": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "description": "Baseline godoc",
+                    "type": "integer"
+                },
+                "current": {
+                    "description": "Current godoc",
+                    "type": "integer"
+                },
+                "delta": {
+                    "description": "Delta contains the difference between the current and the baseline count",
+                    "type": "integer"
+                },
+                "deltaPercent": {
+                    "description": "DeltaPercent contains the delta relative to the baseline count. It is not set if the baseline count is zero",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "Status is either added, removed, changed or unchanged",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetCompareResponseEventType": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseDelta"
+                },
+                "type": {
+                    "description": "Type godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetCompareResponseKeptnService": {
+            "type": "object",
+            "properties": {
+                "eventTypes": {
+                    "description": "EventTypes contains the number of executions per event type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetCompareResponseEventType"
+                    }
+                },
+                "executions": {
+                    "description": "Executions godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseDelta"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetCompareResponseProject": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events contains the number of events of all services of the project",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseDelta"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetCompareResponseService"
+                    }
+                }
+            }
+        },
+        "operations.GetCompareResponseService": {
+            "type": "object",
+            "properties": {
+                "eventTypes": {
+                    "description": "EventTypes contains the number of events per event type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetCompareResponseEventType"
+                    }
+                },
+                "events": {
+                    "description": "Events godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetCompareResponseDelta"
+                },
+                "keptnServices": {
+                    "description": "KeptnServices godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetCompareResponseKeptnService"
+                    }
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetCompareResponseTimeFrame": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetDoraResponse": {
+            "type": "object",
+            "properties": {
+                "dora": {
+                    "description": "Dora godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetDoraResponseMetrics"
+                },
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "projects": {
+                    "description": "Projects godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetDoraResponseProject"
+                    }
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetDoraResponseMetrics": {
+            "type": "object",
+            "properties": {
+                "changeFailureRate": {
+                    "description": "ChangeFailureRate contains the ratio of failed deployments",
+                    "type": "number"
+                },
+                "deploymentFrequency": {
+                    "description": "DeploymentFrequency contains the average number of deployments per day within the requested time frame",
+                    "type": "number"
+                },
+                "deployments": {
+                    "description": "Deployments godoc",
+                    "type": "integer"
+                },
+                "failedDeployments": {
+                    "description": "FailedDeployments godoc",
+                    "type": "integer"
+                },
+                "incidents": {
+                    "description": "Incidents godoc",
+                    "type": "integer"
+                },
+                "leadTimeForChanges": {
+                    "description": "LeadTimeForChanges godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseDuration"
+                },
+                "timeToRestore": {
+                    "description": "TimeToRestore godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseDuration"
+                }
+            }
+        },
+        "operations.GetDoraResponseProject": {
+            "type": "object",
+            "properties": {
+                "dora": {
+                    "description": "Dora godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetDoraResponseMetrics"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services contains the DORA metrics of the project's services across all stages",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetDoraResponseService"
+                    }
+                },
+                "stages": {
+                    "description": "Stages godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetDoraResponseStage"
+                    }
+                }
+            }
+        },
+        "operations.GetDoraResponseService": {
+            "type": "object",
+            "properties": {
+                "dora": {
+                    "description": "Dora godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetDoraResponseMetrics"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetDoraResponseStage": {
+            "type": "object",
+            "properties": {
+                "dora": {
+                    "description": "Dora godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetDoraResponseMetrics"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetDoraResponseService"
+                    }
+                }
+            }
+        },
+        "operations.GetEvaluationsResponse": {
+            "type": "object",
+            "properties": {
+                "evaluations": {
+                    "description": "Evaluations godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseEvaluations"
+                },
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "projects": {
+                    "description": "Projects godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetEvaluationsResponseProject"
+                    }
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetEvaluationsResponseProject": {
+            "type": "object",
+            "properties": {
+                "evaluations": {
+                    "description": "Evaluations godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseEvaluations"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services contains the evaluations of the project's services across all stages",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetEvaluationsResponseService"
+                    }
+                },
+                "stages": {
+                    "description": "Stages godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetEvaluationsResponseStage"
+                    }
+                }
+            }
+        },
+        "operations.GetEvaluationsResponseService": {
+            "type": "object",
+            "properties": {
+                "evaluations": {
+                    "description": "Evaluations godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseEvaluations"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetEvaluationsResponseStage": {
+            "type": "object",
+            "properties": {
+                "evaluations": {
+                    "description": "Evaluations godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.GetStatisticsResponseEvaluations"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetEvaluationsResponseService"
+                    }
+                }
+            }
+        },
+        "operations.GetKeptnServicesResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "projects": {
+                    "description": "Projects godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetKeptnServicesResponseProject"
+                    }
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetKeptnServicesResponseKeptnService": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "versions": {
+                    "description": "Versions godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.KeptnServiceVersion"
+                    }
+                }
+            }
+        },
+        "operations.GetKeptnServicesResponseProject": {
+            "type": "object",
+            "properties": {
+                "keptnServices": {
+                    "description": "KeptnServices contains the Keptn services that have sent events for the project",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetKeptnServicesResponseKeptnService"
+                    }
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetStatisticsResponseDuration": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "histogram": {
+                    "description": "Histogram godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetStatisticsResponseHistogramBucket"
+                    }
+                },
+                "max": {
+                    "description": "Max godoc",
+                    "type": "number"
+                },
+                "mean": {
+                    "description": "Mean godoc",
+                    "type": "number"
+                },
+                "min": {
+                    "description": "Min godoc",
+                    "type": "number"
+                },
+                "p50": {
+                    "description": "P50 godoc",
+                    "type": "number"
+                },
+                "p90": {
+                    "description": "P90 godoc",
+                    "type": "number"
+                },
+                "p95": {
+                    "description": "P95 godoc",
+                    "type": "number"
+                },
+                "p99": {
+                    "description": "P99 godoc",
+                    "type": "number"
+                },
+                "sum": {
+                    "description": "Sum godoc",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "Type godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetStatisticsResponseEvaluations": {
+            "type": "object",
+            "properties": {
+                "averageScore": {
+                    "description": "AverageScore godoc",
+                    "type": "number"
+                },
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "fail": {
+                    "description": "Fail godoc",
+                    "type": "integer"
+                },
+                "failRatio": {
+                    "description": "FailRatio godoc",
+                    "type": "number"
+                },
+                "pass": {
+                    "description": "Pass godoc",
+                    "type": "integer"
+                },
+                "passRatio": {
+                    "description": "PassRatio godoc",
+                    "type": "number"
+                },
+                "scoreDistribution": {
+                    "description": "ScoreDistribution godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetStatisticsResponseScoreBucket"
+                    }
+                },
+                "warning": {
+                    "description": "Warning godoc",
+                    "type": "integer"
+                },
+                "warningRatio": {
+                    "description": "WarningRatio godoc",
+                    "type": "number"
+                }
+            }
+        },
+        "operations.GetStatisticsResponseHistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "le": {
+                    "description": "Le is the upper bound of the bucket in seconds, or +Inf for the overflow bucket",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetStatisticsResponseScoreBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "From godoc",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "integer"
+                }
+            }
+        },
+        "operations.GetSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "groupBy": {
+                    "description": "GroupBy godoc",
+                    "type": "string"
+                },
+                "groups": {
+                    "description": "Groups godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetSummaryResponseGroup"
+                    }
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetSummaryResponseExecution": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetSummaryResponseGroup": {
+            "type": "object",
+            "properties": {
+                "automationUnits": {
+                    "description": "AutomationUnits godoc",
+                    "type": "integer"
+                },
+                "keptnServiceExecutions": {
+                    "description": "KeptnServiceExecutions godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetSummaryResponseExecution"
+                    }
+                },
+                "project": {
+                    "description": "Project is empty for the overall summary",
+                    "type": "string"
+                },
+                "service": {
+                    "description": "Service is only set for service summaries",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetTimeseriesResponse": {
+            "type": "object",
+            "properties": {
+                "dataPoints": {
+                    "description": "DataPoints contains one data point per step, including steps without any statistics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetTimeseriesResponseDataPoint"
+                    }
+                },
+                "from": {
+                    "description": "From godoc",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "Step godoc",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.GetTimeseriesResponseDataPoint": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From godoc",
                     "type": "string"
                 },
-                "specversion": {
+                "to": {
+                    "description": "To godoc",
                     "type": "string"
                 },
-                "time": {
+                "values": {
+                    "description": "Values contains the value of each selected counter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "operations.GetTopResponse": {
+            "type": "object",
+            "properties": {
+                "by": {
+                    "description": "By godoc",
                     "type": "string"
                 },
-                "triggeredid": {
+                "entries": {
+                    "description": "Entries godoc",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.GetTopResponseEntry"
+                    }
+                },
+                "from": {
+                    "description": "From godoc",
                     "type": "string"
                 },
-                "type": {
+                "metric": {
+                    "description": "Metric godoc",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To godoc",
                     "type": "string"
+                },
+                "total": {
+                    "description": "Total contains the sum of the metric over all entities, including the ones that are not part of the top entries",
+                    "type": "integer"
                 }
             }
         },
-        "operations.Project": {
+        "operations.GetTopResponseEntry": {
             "type": "object",
             "properties": {
+                "count": {
+                    "description": "Count godoc",
+                    "type": "integer"
+                },
                 "name": {
+                    "description": "Name godoc",
                     "type": "string"
                 },
-                "services": {
+                "project": {
+                    "description": "Project is only set when ranking services, since service names are only unique within a project",
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Rank starts at 1",
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Share contains the ratio of the count to the total",
+                    "type": "number"
+                }
+            }
+        },
+        "operations.HyperLogLog": {
+            "type": "object",
+            "properties": {
+                "registers": {
+                    "description": "Registers contains the ranks of all registers in the dense encoding; it is empty as long as the sketch is sparse",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "sparse": {
+                    "description": "Sparse contains the registers that are set in the sparse encoding, ordered by their index",
                     "type": "array",
                     "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "operations.KeptnBase": {
+            "type": "object",
+            "properties": {
+                "ProblemID": {
+                    "description": "ProblemID contains the ID of the problem a problem event belongs to",
+                    "type": "string"
+                },
+                "State": {
+                    "description": "State contains the state of a problem event (e.g. OPEN, RESOLVED or CLOSED)",
+                    "type": "string"
+                },
+                "action": {
+                    "description": "Action contains the remediation action of an action.triggered or action.finished event",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.ActionDetails"
+                },
+                "approval": {
+                    "description": "Approval contains the approval strategy of an approval.triggered event or, for Keptn \u003c 0.8, the decision of an approval.finished event",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.ApprovalDetails"
+                },
+                "evaluation": {
+                    "description": "Evaluation contains the result of an evaluation.finished event",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.EvaluationDetails"
+                },
+                "evaluationdetails": {
+                    "description": "EvaluationDetails contains the result of an evaluation-done event (Keptn \u003c 0.8)",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.EvaluationDetails"
+                },
+                "labels": {
+                    "description": "Labels contains the labels of the event, e.g. the team or the build number",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "project": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.KeptnService": {
+            "type": "object",
+            "properties": {
+                "durations": {
+                    "description": "Durations contains the durations of the tasks executed by the Keptn service per task type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.DurationStatistics"
+                    }
+                },
+                "executions": {
+                    "description": "Executions godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "taskResults": {
+                    "description": "TaskResults contains the results and statuses of the finished events sent by the Keptn service per task type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.TaskResults"
+                    }
+                }
+            }
+        },
+        "operations.KeptnServiceInventory": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "versions": {
+                    "description": "Versions godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.KeptnServiceVersion"
+                    }
+                }
+            }
+        },
+        "operations.KeptnServiceVersion": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events contains the number of events the Keptn service has sent in this version",
+                    "type": "integer"
+                },
+                "firstSeen": {
+                    "description": "FirstSeen godoc",
+                    "type": "string"
+                },
+                "lastSeen": {
+                    "description": "LastSeen godoc",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version godoc",
+                    "type": "string"
+                }
+            }
+        },
+        "operations.LabelStatistics": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events godoc",
+                    "type": "integer"
+                },
+                "executedSequences": {
+                    "description": "ExecutedSequences godoc",
+                    "type": "integer"
+                },
+                "keptnServiceExecutions": {
+                    "description": "KeptnServiceExecutions godoc",
+                    "type": "integer"
+                }
+            }
+        },
+        "operations.Project": {
+            "type": "object",
+            "properties": {
+                "keptnServices": {
+                    "description": "KeptnServices contains the versions of the Keptn services that have sent events for the project",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.KeptnServiceInventory"
+                    }
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "services": {
+                    "description": "Services contains the statistics of the project's services. If the statistics are StagesOnly, it only contains events without a stage",
+                    "type": "object",
+                    "additionalProperties": {
                         "$ref": "#/definitions/operations.Service"
                     }
+                },
+                "stages": {
+                    "description": "Stages contains the statistics of the project's services, broken down by stage. Buckets created before stages have been tracked do not contain this property",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.Stage"
+                    }
+                }
+            }
+        },
+        "operations.RemediationActionStatistics": {
+            "type": "object",
+            "properties": {
+                "finished": {
+                    "description": "Finished godoc",
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "description": "Succeeded godoc",
+                    "type": "integer"
+                },
+                "triggered": {
+                    "description": "Triggered godoc",
+                    "type": "integer"
+                }
+            }
+        },
+        "operations.RemediationStatistics": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Actions contains the executions of remediation actions per action type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.RemediationActionStatistics"
+                    }
+                },
+                "problemsClosed": {
+                    "description": "ProblemsClosed godoc",
+                    "type": "integer"
+                },
+                "problemsOpened": {
+                    "description": "ProblemsOpened godoc",
+                    "type": "integer"
+                },
+                "remediationsTriggered": {
+                    "description": "RemediationsTriggered contains the number of remediation sequences that have been triggered",
+                    "type": "integer"
                 }
             }
         },
         "operations.Service": {
             "type": "object",
             "properties": {
+                "approvals": {
+                    "description": "Approvals godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.ApprovalStatistics"
+                },
+                "dora": {
+                    "description": "Dora contains the signals that are needed to compute the DORA metrics of the service",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.DoraStatistics"
+                },
+                "evaluations": {
+                    "description": "Evaluations godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.EvaluationStatistics"
+                },
                 "events": {
+                    "description": "Events godoc",
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
                     }
                 },
                 "executedSequences": {
+                    "description": "ExecutedSequences godoc",
                     "type": "integer"
                 },
+                "executedSequencesPerType": {
+                    "description": "ExecutedSequencesPerType godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "keptnServiceExecutions": {
+                    "description": "KeptnServiceExecutions godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.KeptnService"
+                    }
+                },
+                "labels": {
+                    "description": "Labels contains the counts per key and value of the labels that have been promoted to dimensions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "$ref": "#/definitions/operations.LabelStatistics"
+                        }
+                    }
+                },
+                "name": {
+                    "description": "Name godoc",
+                    "type": "string"
+                },
+                "remediation": {
+                    "description": "Remediation godoc",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.RemediationStatistics"
+                },
+                "sequenceDurations": {
+                    "description": "SequenceDurations contains the durations of completed sequences per sequence type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.DurationStatistics"
+                    }
+                },
+                "triggers": {
+                    "description": "Triggers contains the number of sequences per trigger type and source of the triggering event",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "uniqueSequences": {
+                    "description": "UniqueSequences contains a sketch of the distinct Keptn contexts that have been observed for the service",
+                    "type": "object",
+                    "$ref": "#/definitions/operations.HyperLogLog"
+                }
+            }
+        },
+        "operations.Stage": {
+            "type": "object",
+            "properties": {
                 "name": {
+                    "description": "Name godoc",
                     "type": "string"
+                },
+                "services": {
+                    "description": "Services godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/operations.Service"
+                    }
                 }
             }
         },
         "operations.Statistics": {
             "type": "object",
             "properties": {
+                "filteredEvents": {
+                    "description": "FilteredEvents contains the number of events per dimension (project, service, source or eventType) that have been dropped by the ingestion filter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "from": {
+                    "description": "From godoc",
                     "type": "string"
                 },
+                "overflows": {
+                    "description": "Overflows contains the number of events per dimension (project, service, keptnService or eventType) that have been counted as OverflowKey because the cardinality limit was reached, and the number of services whose uniqueSequences have been dropped because the bucket exceeded its maximum size",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "projects": {
-                    "type": "array",
-                    "items": {
+                    "description": "Projects godoc",
+                    "type": "object",
+                    "additionalProperties": {
                         "$ref": "#/definitions/operations.Project"
                     }
                 },
+                "stagesOnly": {
+                    "description": "StagesOnly is set if the events of a stage are only counted in the statistics of the stage. Otherwise, the services of a project contain the totals of all stages",
+                    "type": "boolean"
+                },
+                "taskNames": {
+                    "description": "TaskNames is set if executions, sequences, durations and task results are counted per task name. Statistics of previous versions count them per event type and are normalized when they are merged",
+                    "type": "boolean"
+                },
                 "to": {
+                    "description": "To godoc",
                     "type": "string"
                 }
             }
+        },
+        "operations.TaskResults": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "description": "Results godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "statuses": {
+                    "description": "Statuses godoc",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -5,6 +5,7 @@ import (
 	"github.com/keptn-sandbox/statistics-service/statistics-service/api"
 	"github.com/keptn-sandbox/statistics-service/statistics-service/controller"
 	docs "github.com/keptn-sandbox/statistics-service/statistics-service/docs" // docs is generated by Swag CLI, you have to import it.
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"os"
@@ -38,10 +39,20 @@ func main() {
 
 	apiV1 := router.Group("/v1")
 	apiV1.GET("/statistics", api.GetStatistics)
+	apiV1.GET("/statistics/timeseries", api.GetTimeseries)
+	apiV1.GET("/statistics/summary", api.GetSummary)
+	apiV1.GET("/statistics/compare", api.GetCompare)
+	apiV1.GET("/statistics/top", api.GetTop)
+	apiV1.GET("/evaluations", api.GetEvaluations)
+	apiV1.GET("/dora", api.GetDora)
+	apiV1.GET("/services", api.GetKeptnServices)
 
 	apiV1.POST("/event", api.HandleEvent)
 
 	router.GET("/swagger-ui/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/health", api.GetHealth)
+	router.GET("/ready", api.GetReady)
 
 	router.Run()
 
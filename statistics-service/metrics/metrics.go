@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const namespace = "keptn_statistics"
+
+// RejectionInvalidPayload denotes requests whose body is not a valid event
+const RejectionInvalidPayload = "invalid_payload"
+
+// RejectionInvalidEvent denotes events without a project, service, type or source
+const RejectionInvalidEvent = "invalid_event"
+
+// RejectionFiltered denotes events that have been dropped by the ingestion filters
+const RejectionFiltered = "filtered"
+
+// EventsReceived counts the events that have been sent to the service
+var EventsReceived = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "events_received_total",
+	Help:      "Number of events that have been sent to the service",
+})
+
+// EventsRejected counts the events that have not been added to the statistics per reason
+var EventsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "events_rejected_total",
+	Help:      "Number of events that have not been added to the statistics, per reason",
+}, []string{"reason"})
+
+// CardinalityOverflows counts the names that have been replaced by operations.OverflowKey because a cardinality limit was reached, per dimension
+var CardinalityOverflows = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "cardinality_overflows_total",
+	Help:      "Number of names that have been counted as __other__ because a cardinality limit was reached, per dimension",
+}, []string{"dimension"})
+
+// BucketFlushDuration godoc
+var BucketFlushDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "bucket_flush_duration_seconds",
+	Help:      "Duration of storing the in-memory bucket in the database",
+	Buckets:   prometheus.DefBuckets,
+})
+
+// BucketFlushFailures godoc
+var BucketFlushFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "bucket_flush_failures_total",
+	Help:      "Number of in-memory buckets that could not be stored in the database",
+})
+
+// BucketSize contains the number of events, projects and services of the in-memory bucket
+var BucketSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "bucket_size",
+	Help:      "Number of events, projects and services of the in-memory bucket",
+}, []string{"dimension"})
+
+// DatabaseOperationDuration contains the latency of the MongoDB operations per operation
+var DatabaseOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "database_operation_duration_seconds",
+	Help:      "Latency of the MongoDB operations",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"operation"})
+
+// ObserveDatabaseOperation records the latency of a database operation that has been started at the given time
+func ObserveDatabaseOperation(operation string, start time.Time) {
+	DatabaseOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// SetBucketSize godoc
+func SetBucketSize(events, projects, services int) {
+	BucketSize.WithLabelValues("events").Set(float64(events))
+	BucketSize.WithLabelValues("projects").Set(float64(projects))
+	BucketSize.WithLabelValues("services").Set(float64(services))
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"github.com/prometheus/client_golang/prometheus"
+	"strings"
+	"sync"
+)
+
+// LabelProject godoc
+const LabelProject = "project"
+
+// LabelService godoc
+const LabelService = "service"
+
+// LabelKeptnService godoc
+const LabelKeptnService = "keptn_service"
+
+// LabelType contains the event type of events and the task name of executions
+const LabelType = "type"
+
+// UsageLabels contains the labels the usage counters may be partitioned by
+var UsageLabels = []string{LabelProject, LabelService, LabelKeptnService, LabelType}
+
+// UsageMetrics counts the events and the Keptn service executions since the start of the service.
+// To keep the number of series bounded, only the configured labels are used, and label combinations beyond the series limit are counted with the value operations.OverflowKey for all labels
+type UsageMetrics struct {
+	labels     []string
+	maxSeries  int
+	events     *prometheus.CounterVec
+	executions *prometheus.CounterVec
+	overflows  prometheus.Counter
+	lock       sync.Mutex
+	// seen contains the accepted label combinations per counter
+	seen map[string]map[string]bool
+}
+
+// NewUsageMetrics creates the usage counters with the given labels and registers them. A maximum number of series of 0 disables the limit
+func NewUsageMetrics(labels []string, maxSeries int, registerer prometheus.Registerer) (*UsageMetrics, error) {
+	usedLabels := []string{}
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if !containsLabel(UsageLabels, label) {
+			return nil, fmt.Errorf("unknown metrics label %s. Supported labels are: %s", label, strings.Join(UsageLabels, ", "))
+		}
+		if !containsLabel(usedLabels, label) {
+			usedLabels = append(usedLabels, label)
+		}
+	}
+	m := &UsageMetrics{
+		labels:    usedLabels,
+		maxSeries: maxSeries,
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usage_events_total",
+			Help:      "Number of events that have been added to the statistics",
+		}, usedLabels),
+		executions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usage_keptn_service_executions_total",
+			Help:      "Number of Keptn service executions that have been added to the statistics",
+		}, usedLabels),
+		overflows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usage_series_overflows_total",
+			Help:      "Number of increments of the usage counters that exceeded the series limit",
+		}),
+		seen: map[string]map[string]bool{},
+	}
+	for _, collector := range []prometheus.Collector{m.events, m.executions, m.overflows} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// AddEvent godoc
+func (m *UsageMetrics) AddEvent(project, service, keptnService, eventType string) {
+	if m == nil {
+		return
+	}
+	m.add(m.events, "events", project, service, keptnService, eventType)
+}
+
+// AddExecution godoc
+func (m *UsageMetrics) AddExecution(project, service, keptnService, taskType string) {
+	if m == nil {
+		return
+	}
+	m.add(m.executions, "executions", project, service, keptnService, taskType)
+}
+
+func (m *UsageMetrics) add(counter *prometheus.CounterVec, name, project, service, keptnService, typ string) {
+	values := map[string]string{
+		LabelProject:      project,
+		LabelService:      service,
+		LabelKeptnService: keptnService,
+		LabelType:         typ,
+	}
+	labelValues := []string{}
+	for _, label := range m.labels {
+		labelValues = append(labelValues, values[label])
+	}
+
+	m.lock.Lock()
+	if m.maxSeries > 0 {
+		if m.seen[name] == nil {
+			m.seen[name] = map[string]bool{}
+		}
+		key := strings.Join(labelValues, "\x00")
+		if !m.seen[name][key] {
+			if len(m.seen[name]) < m.maxSeries {
+				m.seen[name][key] = true
+			} else {
+				for index := range labelValues {
+					labelValues[index] = operations.OverflowKey
+				}
+				m.overflows.Inc()
+			}
+		}
+	}
+	m.lock.Unlock()
+
+	counter.WithLabelValues(labelValues...).Inc()
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/keptn-sandbox/statistics-service/statistics-service/operations"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+)
+
+func TestUsageMetrics(t *testing.T) {
+	m, err := NewUsageMetrics([]string{LabelProject, LabelKeptnService}, 2, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewUsageMetrics(): unexpected error: %s", err.Error())
+	}
+
+	m.AddEvent("sockshop", "carts", "helm-service", "sh.keptn.event.deployment.finished")
+	m.AddEvent("sockshop", "carts-db", "helm-service", "sh.keptn.event.deployment.finished")
+	m.AddEvent("sockshop", "carts", "jmeter-service", "sh.keptn.event.test.finished")
+	// the series limit has been reached, so new label combinations are counted as overflow
+	m.AddEvent("podtato-head", "helloservice", "helm-service", "sh.keptn.event.deployment.finished")
+	m.AddEvent("sockshop", "carts", "helm-service", "sh.keptn.event.deployment.triggered")
+	m.AddExecution("podtato-head", "helloservice", "helm-service", "deployment")
+
+	if got := testutil.ToFloat64(m.events.WithLabelValues("sockshop", "helm-service")); got != 3 {
+		t.Errorf("AddEvent(): want %d events of helm-service, got %v", 3, got)
+	}
+	if got := testutil.ToFloat64(m.events.WithLabelValues(operations.OverflowKey, operations.OverflowKey)); got != 1 {
+		t.Errorf("AddEvent(): want %d overflowing event, got %v", 1, got)
+	}
+	if got := testutil.ToFloat64(m.overflows); got != 1 {
+		t.Errorf("AddEvent(): want %d overflow, got %v", 1, got)
+	}
+	if got := testutil.ToFloat64(m.executions.WithLabelValues("podtato-head", "helm-service")); got != 1 {
+		t.Errorf("AddExecution(): want %d execution, got %v", 1, got)
+	}
+
+	var nilMetrics *UsageMetrics
+	nilMetrics.AddEvent("sockshop", "carts", "helm-service", "sh.keptn.event.deployment.finished")
+
+	if _, err := NewUsageMetrics([]string{"stage"}, 0, prometheus.NewRegistry()); err == nil {
+		t.Error("NewUsageMetrics(): expected an error for an unknown label")
+	}
+}
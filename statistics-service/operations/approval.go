@@ -0,0 +1,70 @@
+package operations
+
+// ApprovalStatistics contains statistics about the approvals of a service
+type ApprovalStatistics struct {
+	// Requested contains the number of approvals that have been triggered
+	Requested int `json:"requested" bson:"requested"`
+	// Approved godoc
+	Approved int `json:"approved" bson:"approved"`
+	// Rejected godoc
+	Rejected int `json:"rejected" bson:"rejected"`
+	// Automatic contains the number of approvals that have been finished automatically by the gatekeeper
+	Automatic int `json:"automatic" bson:"automatic"`
+	// Manual contains the number of approvals that have been finished by a user
+	Manual int `json:"manual" bson:"manual"`
+	// TimesToApprove contains the durations between the triggering and the finishing of approvals
+	TimesToApprove *DurationStatistics `json:"timesToApprove,omitempty" bson:"timesToApprove,omitempty"`
+}
+
+// Merge adds the values of the given statistics
+func (a *ApprovalStatistics) Merge(other *ApprovalStatistics) {
+	if other == nil {
+		return
+	}
+	a.Requested = a.Requested + other.Requested
+	a.Approved = a.Approved + other.Approved
+	a.Rejected = a.Rejected + other.Rejected
+	a.Automatic = a.Automatic + other.Automatic
+	a.Manual = a.Manual + other.Manual
+	if other.TimesToApprove != nil {
+		if a.TimesToApprove == nil {
+			a.TimesToApprove = NewDurationStatistics()
+		}
+		a.TimesToApprove.Merge(other.TimesToApprove)
+	}
+}
+
+// GetStatisticsResponseApprovals godoc
+type GetStatisticsResponseApprovals struct {
+	// Requested godoc
+	Requested int `json:"requested" bson:"requested"`
+	// Approved godoc
+	Approved int `json:"approved" bson:"approved"`
+	// Rejected godoc
+	Rejected int `json:"rejected" bson:"rejected"`
+	// Automatic godoc
+	Automatic int `json:"automatic" bson:"automatic"`
+	// Manual godoc
+	Manual int `json:"manual" bson:"manual"`
+	// TimeToApprove godoc
+	TimeToApprove *GetStatisticsResponseDuration `json:"timeToApprove,omitempty" bson:"timeToApprove,omitempty"`
+}
+
+// ToResponse converts the approval statistics into their API representation. If no approvals have been recorded, nil is returned
+func (a *ApprovalStatistics) ToResponse() *GetStatisticsResponseApprovals {
+	if a == nil || (a.Requested == 0 && a.Approved == 0 && a.Rejected == 0) {
+		return nil
+	}
+	result := &GetStatisticsResponseApprovals{
+		Requested: a.Requested,
+		Approved:  a.Approved,
+		Rejected:  a.Rejected,
+		Automatic: a.Automatic,
+		Manual:    a.Manual,
+	}
+	if a.TimesToApprove != nil {
+		timeToApprove := a.TimesToApprove.ToResponse("timeToApprove")
+		result.TimeToApprove = &timeToApprove
+	}
+	return result
+}
@@ -0,0 +1,289 @@
+package operations
+
+import (
+	"sort"
+	"time"
+)
+
+// CompareStatusAdded denotes entries that only occur in the current time frame
+const CompareStatusAdded = "added"
+
+// CompareStatusRemoved denotes entries that only occur in the baseline time frame
+const CompareStatusRemoved = "removed"
+
+// CompareStatusChanged godoc
+const CompareStatusChanged = "changed"
+
+// CompareStatusUnchanged godoc
+const CompareStatusUnchanged = "unchanged"
+
+// GetCompareParams godoc
+type GetCompareParams struct {
+	GetStatisticsParams
+	// BaselineFrom contains the start of the baseline time frame in any of the formats accepted by 'from'
+	BaselineFrom string `form:"baselineFrom" json:"baselineFrom"`
+	// BaselineTo contains the end of the baseline time frame in any of the formats accepted by 'to'
+	BaselineTo string `form:"baselineTo" json:"baselineTo"`
+	// BaselinePeriod selects a calendar period, e.g. lastMonth, as the baseline time frame
+	BaselinePeriod string `form:"baselinePeriod" json:"baselinePeriod"`
+}
+
+// GetBaselineParams returns the parameters of the baseline time frame. The filters and the time zone of the current time frame are applied to the baseline as well.
+// If no baseline is set, the time frame of the same length immediately before the current time frame is used
+func (p GetCompareParams) GetBaselineParams(now time.Time) (GetStatisticsParams, error) {
+	baseline := p.GetStatisticsParams
+	baseline.FromParam = p.BaselineFrom
+	baseline.ToParam = p.BaselineTo
+	baseline.Period = p.BaselinePeriod
+	baseline.From = time.Time{}
+	baseline.To = time.Time{}
+	if p.BaselineFrom == "" && p.BaselineTo == "" && p.BaselinePeriod == "" {
+		baseline.From = p.From.Add(-p.To.Sub(p.From))
+		baseline.To = p.From
+		return baseline, nil
+	}
+	if err := baseline.ResolveTimeFrame(now); err != nil {
+		return GetStatisticsParams{}, err
+	}
+	return baseline, nil
+}
+
+// GetCompareResponseTimeFrame godoc
+type GetCompareResponseTimeFrame struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+}
+
+// GetCompareResponseDelta contains a count of the baseline and of the current time frame
+type GetCompareResponseDelta struct {
+	// Baseline godoc
+	Baseline int `json:"baseline" bson:"baseline"`
+	// Current godoc
+	Current int `json:"current" bson:"current"`
+	// Delta contains the difference between the current and the baseline count
+	Delta int `json:"delta" bson:"delta"`
+	// DeltaPercent contains the delta relative to the baseline count. It is not set if the baseline count is zero
+	DeltaPercent *float64 `json:"deltaPercent,omitempty" bson:"deltaPercent,omitempty"`
+	// Status is either added, removed, changed or unchanged
+	Status string `json:"status" bson:"status"`
+}
+
+// NewCompareDelta godoc
+func NewCompareDelta(baseline, current int) GetCompareResponseDelta {
+	result := GetCompareResponseDelta{
+		Baseline: baseline,
+		Current:  current,
+		Delta:    current - baseline,
+	}
+	if baseline != 0 {
+		deltaPercent := float64(result.Delta) / float64(baseline) * 100
+		result.DeltaPercent = &deltaPercent
+	}
+	switch {
+	case baseline == 0 && current > 0:
+		result.Status = CompareStatusAdded
+	case baseline > 0 && current == 0:
+		result.Status = CompareStatusRemoved
+	case result.Delta != 0:
+		result.Status = CompareStatusChanged
+	default:
+		result.Status = CompareStatusUnchanged
+	}
+	return result
+}
+
+// GetCompareResponse godoc
+type GetCompareResponse struct {
+	// Baseline godoc
+	Baseline GetCompareResponseTimeFrame `json:"baseline" bson:"baseline"`
+	// Current godoc
+	Current GetCompareResponseTimeFrame `json:"current" bson:"current"`
+	// Events contains the number of events of all projects
+	Events GetCompareResponseDelta `json:"events" bson:"events"`
+	// Projects godoc
+	Projects []GetCompareResponseProject `json:"projects" bson:"projects"`
+}
+
+// GetCompareResponseProject godoc
+type GetCompareResponseProject struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Events contains the number of events of all services of the project
+	Events GetCompareResponseDelta `json:"events" bson:"events"`
+	// Services godoc
+	Services []GetCompareResponseService `json:"services" bson:"services"`
+}
+
+// GetCompareResponseService godoc
+type GetCompareResponseService struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Events godoc
+	Events GetCompareResponseDelta `json:"events" bson:"events"`
+	// EventTypes contains the number of events per event type
+	EventTypes []GetCompareResponseEventType `json:"eventTypes" bson:"eventTypes"`
+	// KeptnServices godoc
+	KeptnServices []GetCompareResponseKeptnService `json:"keptnServices" bson:"keptnServices"`
+}
+
+// GetCompareResponseKeptnService godoc
+type GetCompareResponseKeptnService struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Executions godoc
+	Executions GetCompareResponseDelta `json:"executions" bson:"executions"`
+	// EventTypes contains the number of executions per event type
+	EventTypes []GetCompareResponseEventType `json:"eventTypes" bson:"eventTypes"`
+}
+
+// GetCompareResponseEventType godoc
+type GetCompareResponseEventType struct {
+	// Type godoc
+	Type string `json:"type" bson:"type"`
+	// Count godoc
+	Count GetCompareResponseDelta `json:"count" bson:"count"`
+}
+
+// CompareStatistics returns the counts of both statistics for every project, service, Keptn service and event type that occurs in any of them
+func CompareStatistics(baseline, current Statistics) GetCompareResponse {
+	result := GetCompareResponse{
+		Baseline: GetCompareResponseTimeFrame{From: baseline.From, To: baseline.To},
+		Current:  GetCompareResponseTimeFrame{From: current.From, To: current.To},
+		Projects: []GetCompareResponseProject{},
+	}
+	baselineTotal := 0
+	currentTotal := 0
+	for _, projectName := range unionKeys(projectNames(baseline.Projects), projectNames(current.Projects)) {
+		baselineProject := getProject(baseline, projectName)
+		currentProject := getProject(current, projectName)
+		newProject := GetCompareResponseProject{
+			Name:     projectName,
+			Services: []GetCompareResponseService{},
+		}
+		baselineProjectTotal := 0
+		currentProjectTotal := 0
+		for _, serviceName := range unionKeys(serviceNames(baselineProject.Services), serviceNames(currentProject.Services)) {
+			newService := compareServices(serviceName, getService(baselineProject, serviceName), getService(currentProject, serviceName))
+			baselineProjectTotal += newService.Events.Baseline
+			currentProjectTotal += newService.Events.Current
+			newProject.Services = append(newProject.Services, newService)
+		}
+		newProject.Events = NewCompareDelta(baselineProjectTotal, currentProjectTotal)
+		baselineTotal += baselineProjectTotal
+		currentTotal += currentProjectTotal
+		result.Projects = append(result.Projects, newProject)
+	}
+	result.Events = NewCompareDelta(baselineTotal, currentTotal)
+	return result
+}
+
+func compareServices(serviceName string, baseline, current *Service) GetCompareResponseService {
+	result := GetCompareResponseService{
+		Name:          serviceName,
+		EventTypes:    compareCounts(baseline.Events, current.Events),
+		KeptnServices: []GetCompareResponseKeptnService{},
+	}
+	result.Events = sumCompareDeltas(result.EventTypes)
+	for _, keptnServiceName := range unionKeys(keptnServiceNames(baseline.KeptnServiceExecutions), keptnServiceNames(current.KeptnServiceExecutions)) {
+		baselineExecutions := map[string]int{}
+		if keptnService := baseline.KeptnServiceExecutions[keptnServiceName]; keptnService != nil {
+			baselineExecutions = keptnService.Executions
+		}
+		currentExecutions := map[string]int{}
+		if keptnService := current.KeptnServiceExecutions[keptnServiceName]; keptnService != nil {
+			currentExecutions = keptnService.Executions
+		}
+		newKeptnService := GetCompareResponseKeptnService{
+			Name:       keptnServiceName,
+			EventTypes: compareCounts(baselineExecutions, currentExecutions),
+		}
+		newKeptnService.Executions = sumCompareDeltas(newKeptnService.EventTypes)
+		result.KeptnServices = append(result.KeptnServices, newKeptnService)
+	}
+	return result
+}
+
+func compareCounts(baseline, current map[string]int) []GetCompareResponseEventType {
+	result := []GetCompareResponseEventType{}
+	for _, eventType := range unionKeys(countNames(baseline), countNames(current)) {
+		result = append(result, GetCompareResponseEventType{
+			Type:  eventType,
+			Count: NewCompareDelta(baseline[eventType], current[eventType]),
+		})
+	}
+	return result
+}
+
+func sumCompareDeltas(eventTypes []GetCompareResponseEventType) GetCompareResponseDelta {
+	baseline := 0
+	current := 0
+	for _, eventType := range eventTypes {
+		baseline += eventType.Count.Baseline
+		current += eventType.Count.Current
+	}
+	return NewCompareDelta(baseline, current)
+}
+
+func getProject(statistics Statistics, projectName string) *Project {
+	if project := statistics.Projects[projectName]; project != nil {
+		return project
+	}
+	return &Project{}
+}
+
+func getService(project *Project, serviceName string) *Service {
+	if service := project.Services[serviceName]; service != nil {
+		return service
+	}
+	return &Service{}
+}
+
+// unionKeys returns the sorted names that occur in any of the given lists
+func unionKeys(lists ...[]string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, list := range lists {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				result = append(result, name)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func projectNames(projects map[string]*Project) []string {
+	result := []string{}
+	for name := range projects {
+		result = append(result, name)
+	}
+	return result
+}
+
+func serviceNames(services map[string]*Service) []string {
+	result := []string{}
+	for name := range services {
+		result = append(result, name)
+	}
+	return result
+}
+
+func keptnServiceNames(keptnServices map[string]*KeptnService) []string {
+	result := []string{}
+	for name := range keptnServices {
+		result = append(result, name)
+	}
+	return result
+}
+
+func countNames(counts map[string]int) []string {
+	result := []string{}
+	for name := range counts {
+		result = append(result, name)
+	}
+	return result
+}
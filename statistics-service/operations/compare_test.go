@@ -0,0 +1,96 @@
+package operations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCompareDelta(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseline      int
+		current       int
+		wantStatus    string
+		wantPercent   float64
+		wantNoPercent bool
+	}{
+		{name: "added", baseline: 0, current: 3, wantStatus: CompareStatusAdded, wantNoPercent: true},
+		{name: "removed", baseline: 4, current: 0, wantStatus: CompareStatusRemoved, wantPercent: -100},
+		{name: "changed", baseline: 4, current: 5, wantStatus: CompareStatusChanged, wantPercent: 25},
+		{name: "unchanged", baseline: 2, current: 2, wantStatus: CompareStatusUnchanged, wantPercent: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCompareDelta(tt.baseline, tt.current)
+			if got.Status != tt.wantStatus || got.Delta != tt.current-tt.baseline {
+				t.Errorf("NewCompareDelta() = %+v, want status %s", got, tt.wantStatus)
+			}
+			if tt.wantNoPercent {
+				if got.DeltaPercent != nil {
+					t.Errorf("NewCompareDelta(): deltaPercent must not be set for a baseline of zero, got %v", *got.DeltaPercent)
+				}
+			} else if got.DeltaPercent == nil || *got.DeltaPercent != tt.wantPercent {
+				t.Errorf("NewCompareDelta(): want deltaPercent %v, got %v", tt.wantPercent, got.DeltaPercent)
+			}
+		})
+	}
+}
+
+func TestCompareStatistics(t *testing.T) {
+	baseline := Statistics{}
+	baseline.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.deployment.finished", 4)
+	baseline.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "helm-service", "deployment", 4)
+	baseline.IncreaseEventTypeCount("sockshop", "dev", "cart-db", "sh.keptn.event.deployment.finished", 1)
+
+	current := Statistics{}
+	current.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.deployment.finished", 6)
+	current.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "helm-service", "deployment", 6)
+	current.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "jmeter-service", "test", 2)
+	current.IncreaseEventTypeCount("podtato-head", "dev", "helloservice", "sh.keptn.event.test.finished", 2)
+
+	got := CompareStatistics(baseline.WithProjectTotals(), current.WithProjectTotals())
+
+	if got.Events.Baseline != 5 || got.Events.Current != 8 {
+		t.Errorf("CompareStatistics(): unexpected total %+v", got.Events)
+	}
+	if len(got.Projects) != 2 || got.Projects[0].Name != "podtato-head" || got.Projects[0].Events.Status != CompareStatusAdded {
+		t.Fatalf("CompareStatistics(): unexpected projects %+v", got.Projects)
+	}
+	sockshop := got.Projects[1]
+	if len(sockshop.Services) != 2 || sockshop.Services[0].Name != "cart-db" || sockshop.Services[0].Events.Status != CompareStatusRemoved {
+		t.Fatalf("CompareStatistics(): unexpected services %+v", sockshop.Services)
+	}
+	carts := sockshop.Services[1]
+	if carts.Events.Delta != 2 || carts.Events.DeltaPercent == nil || *carts.Events.DeltaPercent != 50 {
+		t.Errorf("CompareStatistics(): unexpected events of carts %+v", carts.Events)
+	}
+	if len(carts.KeptnServices) != 2 || carts.KeptnServices[1].Name != "jmeter-service" || carts.KeptnServices[1].EventTypes[0].Count.Status != CompareStatusAdded {
+		t.Errorf("CompareStatistics(): unexpected Keptn services of carts %+v", carts.KeptnServices)
+	}
+}
+
+func TestGetCompareParams_GetBaselineParams(t *testing.T) {
+	now := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	params := GetCompareParams{GetStatisticsParams: GetStatisticsParams{
+		Project: "sockshop",
+		From:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2020, 10, 15, 0, 0, 0, 0, time.UTC),
+	}}
+
+	got, err := params.GetBaselineParams(now)
+	if err != nil {
+		t.Fatalf("GetBaselineParams(): unexpected error: %s", err.Error())
+	}
+	if !got.From.Equal(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)) || !got.To.Equal(params.From) || got.Project != "sockshop" {
+		t.Errorf("GetBaselineParams(): the default baseline must precede the current time frame, got %+v", got)
+	}
+
+	params.BaselinePeriod = PeriodLastMonth
+	got, err = params.GetBaselineParams(now)
+	if err != nil {
+		t.Fatalf("GetBaselineParams(): unexpected error: %s", err.Error())
+	}
+	if !got.From.Equal(time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)) || !got.To.Equal(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetBaselineParams(): unexpected baseline period %v - %v", got.From, got.To)
+	}
+}
@@ -0,0 +1,129 @@
+package operations
+
+import (
+	"time"
+)
+
+// DoraStatistics contains the signals that are needed to compute the DORA metrics (deployment frequency, lead time for changes,
+// change failure rate and time to restore service) of a service
+type DoraStatistics struct {
+	// Deployments contains the number of finished deployments
+	Deployments int `json:"deployments" bson:"deployments"`
+	// FailedDeployments contains the number of deployments that have failed or whose subsequent evaluation has failed
+	FailedDeployments int `json:"failedDeployments" bson:"failedDeployments"`
+	// LeadTimes contains the durations between a configuration change and the successful deployment of the change
+	LeadTimes *DurationStatistics `json:"leadTimes,omitempty" bson:"leadTimes,omitempty"`
+	// Incidents contains the number of problems that have been opened
+	Incidents int `json:"incidents" bson:"incidents"`
+	// TimesToRestore contains the durations between the opening and the closing of a problem
+	TimesToRestore *DurationStatistics `json:"timesToRestore,omitempty" bson:"timesToRestore,omitempty"`
+}
+
+// Merge adds the values of the given statistics
+func (d *DoraStatistics) Merge(other *DoraStatistics) {
+	if other == nil {
+		return
+	}
+	d.Deployments = d.Deployments + other.Deployments
+	d.FailedDeployments = d.FailedDeployments + other.FailedDeployments
+	d.Incidents = d.Incidents + other.Incidents
+	if other.LeadTimes != nil {
+		if d.LeadTimes == nil {
+			d.LeadTimes = NewDurationStatistics()
+		}
+		d.LeadTimes.Merge(other.LeadTimes)
+	}
+	if other.TimesToRestore != nil {
+		if d.TimesToRestore == nil {
+			d.TimesToRestore = NewDurationStatistics()
+		}
+		d.TimesToRestore.Merge(other.TimesToRestore)
+	}
+}
+
+// GetDoraResponseMetrics godoc
+type GetDoraResponseMetrics struct {
+	// Deployments godoc
+	Deployments int `json:"deployments" bson:"deployments"`
+	// FailedDeployments godoc
+	FailedDeployments int `json:"failedDeployments" bson:"failedDeployments"`
+	// DeploymentFrequency contains the average number of deployments per day within the requested time frame
+	DeploymentFrequency float64 `json:"deploymentFrequency" bson:"deploymentFrequency"`
+	// LeadTimeForChanges godoc
+	LeadTimeForChanges *GetStatisticsResponseDuration `json:"leadTimeForChanges,omitempty" bson:"leadTimeForChanges,omitempty"`
+	// ChangeFailureRate contains the ratio of failed deployments
+	ChangeFailureRate float64 `json:"changeFailureRate" bson:"changeFailureRate"`
+	// Incidents godoc
+	Incidents int `json:"incidents" bson:"incidents"`
+	// TimeToRestore godoc
+	TimeToRestore *GetStatisticsResponseDuration `json:"timeToRestore,omitempty" bson:"timeToRestore,omitempty"`
+}
+
+// ToResponse converts the statistics into the DORA metrics for the given time frame
+func (d *DoraStatistics) ToResponse(from, to time.Time) *GetDoraResponseMetrics {
+	if d == nil {
+		d = &DoraStatistics{}
+	}
+	result := &GetDoraResponseMetrics{
+		Deployments:       d.Deployments,
+		FailedDeployments: d.FailedDeployments,
+		Incidents:         d.Incidents,
+	}
+	if days := to.Sub(from).Hours() / 24; days > 0 {
+		result.DeploymentFrequency = float64(d.Deployments) / days
+	}
+	if d.Deployments > 0 {
+		result.ChangeFailureRate = float64(d.FailedDeployments) / float64(d.Deployments)
+	}
+	if d.LeadTimes != nil {
+		leadTime := d.LeadTimes.ToResponse("leadTimeForChanges")
+		result.LeadTimeForChanges = &leadTime
+	}
+	if d.TimesToRestore != nil {
+		timeToRestore := d.TimesToRestore.ToResponse("timeToRestore")
+		result.TimeToRestore = &timeToRestore
+	}
+	return result
+}
+
+// GetDoraResponse godoc
+type GetDoraResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// Dora godoc
+	Dora *GetDoraResponseMetrics `json:"dora" bson:"dora"`
+	// Projects godoc
+	Projects []GetDoraResponseProject `json:"projects" bson:"projects"`
+}
+
+// GetDoraResponseProject godoc
+type GetDoraResponseProject struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Dora godoc
+	Dora *GetDoraResponseMetrics `json:"dora" bson:"dora"`
+	// Services contains the DORA metrics of the project's services across all stages
+	Services []GetDoraResponseService `json:"services" bson:"services"`
+	// Stages godoc
+	Stages []GetDoraResponseStage `json:"stages" bson:"stages"`
+}
+
+// GetDoraResponseStage godoc
+type GetDoraResponseStage struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Dora godoc
+	Dora *GetDoraResponseMetrics `json:"dora" bson:"dora"`
+	// Services godoc
+	Services []GetDoraResponseService `json:"services" bson:"services"`
+}
+
+// GetDoraResponseService godoc
+type GetDoraResponseService struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Dora godoc
+	Dora *GetDoraResponseMetrics `json:"dora" bson:"dora"`
+}
@@ -0,0 +1,50 @@
+package operations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoraStatistics_ToResponse(t *testing.T) {
+	first := &DoraStatistics{
+		Deployments:       3,
+		FailedDeployments: 1,
+		LeadTimes:         NewDurationStatistics(),
+	}
+	first.LeadTimes.Add(20 * time.Second)
+	second := &DoraStatistics{
+		Deployments:    1,
+		Incidents:      1,
+		TimesToRestore: NewDurationStatistics(),
+	}
+	second.TimesToRestore.Add(time.Hour)
+
+	merged := &DoraStatistics{}
+	merged.Merge(first)
+	merged.Merge(second)
+	merged.Merge(nil)
+
+	from := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	got := merged.ToResponse(from, from.Add(48*time.Hour))
+
+	if got.Deployments != 4 || got.FailedDeployments != 1 || got.Incidents != 1 {
+		t.Errorf("DoraStatistics.ToResponse(): unexpected counts: %v", got)
+	}
+	if got.DeploymentFrequency != 2 {
+		t.Errorf("DoraStatistics.ToResponse(): want deployment frequency %v, got %v", 2, got.DeploymentFrequency)
+	}
+	if got.ChangeFailureRate != 0.25 {
+		t.Errorf("DoraStatistics.ToResponse(): want change failure rate %v, got %v", 0.25, got.ChangeFailureRate)
+	}
+	if got.LeadTimeForChanges == nil || got.LeadTimeForChanges.Mean != 20 {
+		t.Errorf("DoraStatistics.ToResponse(): unexpected lead time: %v", got.LeadTimeForChanges)
+	}
+	if got.TimeToRestore == nil || got.TimeToRestore.Mean != 3600 {
+		t.Errorf("DoraStatistics.ToResponse(): unexpected time to restore: %v", got.TimeToRestore)
+	}
+
+	var empty *DoraStatistics
+	if got := empty.ToResponse(from, from); got.DeploymentFrequency != 0 || got.LeadTimeForChanges != nil {
+		t.Errorf("DoraStatistics.ToResponse(): unexpected metrics for empty statistics: %v", got)
+	}
+}
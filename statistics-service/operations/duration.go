@@ -0,0 +1,187 @@
+package operations
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// DurationHistogramBounds contains the upper bounds (in seconds) of the histogram buckets used by DurationStatistics.
+// Durations exceeding the last bound are counted in an additional overflow bucket
+var DurationHistogramBounds = []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600, 86400}
+
+// DurationStatistics is a mergeable summary of observed durations. All values are in seconds
+type DurationStatistics struct {
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+	// Sum godoc
+	Sum float64 `json:"sum" bson:"sum"`
+	// Min godoc
+	Min float64 `json:"min" bson:"min"`
+	// Max godoc
+	Max float64 `json:"max" bson:"max"`
+	// Histogram contains the number of durations per bucket defined by DurationHistogramBounds
+	Histogram []int `json:"histogram" bson:"histogram"`
+}
+
+// NewDurationStatistics godoc
+func NewDurationStatistics() *DurationStatistics {
+	return &DurationStatistics{
+		Histogram: make([]int, len(DurationHistogramBounds)+1),
+	}
+}
+
+// Add adds an observed duration
+func (d *DurationStatistics) Add(duration time.Duration) {
+	seconds := duration.Seconds()
+	if seconds < 0 {
+		seconds = 0
+	}
+	d.ensureHistogram()
+
+	if d.Count == 0 || seconds < d.Min {
+		d.Min = seconds
+	}
+	if d.Count == 0 || seconds > d.Max {
+		d.Max = seconds
+	}
+	d.Count = d.Count + 1
+	d.Sum = d.Sum + seconds
+
+	bucket := len(DurationHistogramBounds)
+	for index, bound := range DurationHistogramBounds {
+		if seconds <= bound {
+			bucket = index
+			break
+		}
+	}
+	d.Histogram[bucket] = d.Histogram[bucket] + 1
+}
+
+// Merge adds the durations of the given statistics
+func (d *DurationStatistics) Merge(other *DurationStatistics) {
+	if other == nil || other.Count == 0 {
+		return
+	}
+	d.ensureHistogram()
+
+	if d.Count == 0 || other.Min < d.Min {
+		d.Min = other.Min
+	}
+	if d.Count == 0 || other.Max > d.Max {
+		d.Max = other.Max
+	}
+	d.Count = d.Count + other.Count
+	d.Sum = d.Sum + other.Sum
+	for index, count := range other.Histogram {
+		if index < len(d.Histogram) {
+			d.Histogram[index] = d.Histogram[index] + count
+		}
+	}
+}
+
+// Mean returns the average duration in seconds
+func (d *DurationStatistics) Mean() float64 {
+	if d == nil || d.Count == 0 {
+		return 0
+	}
+	return d.Sum / float64(d.Count)
+}
+
+// Percentile estimates the given percentile (0-100) in seconds, based on the histogram
+func (d *DurationStatistics) Percentile(percentile float64) float64 {
+	if d == nil || d.Count == 0 {
+		return 0
+	}
+	rank := percentile / 100 * float64(d.Count)
+	cumulative := 0
+	for index, count := range d.Histogram {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative = cumulative + count
+			continue
+		}
+		if index >= len(DurationHistogramBounds) {
+			return d.Max
+		}
+		lowerBound := 0.0
+		if index > 0 {
+			lowerBound = DurationHistogramBounds[index-1]
+		}
+		upperBound := DurationHistogramBounds[index]
+
+		// interpolate linearly within the bucket and make sure the estimate lies within the observed range
+		estimate := lowerBound + (upperBound-lowerBound)*(rank-float64(cumulative))/float64(count)
+		return math.Min(math.Max(estimate, d.Min), d.Max)
+	}
+	return d.Max
+}
+
+func (d *DurationStatistics) ensureHistogram() {
+	if len(d.Histogram) != len(DurationHistogramBounds)+1 {
+		histogram := make([]int, len(DurationHistogramBounds)+1)
+		copy(histogram, d.Histogram)
+		d.Histogram = histogram
+	}
+}
+
+// GetStatisticsResponseDuration godoc
+type GetStatisticsResponseDuration struct {
+	// Type godoc
+	Type string `json:"type" bson:"type"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+	// Sum godoc
+	Sum float64 `json:"sum" bson:"sum"`
+	// Min godoc
+	Min float64 `json:"min" bson:"min"`
+	// Max godoc
+	Max float64 `json:"max" bson:"max"`
+	// Mean godoc
+	Mean float64 `json:"mean" bson:"mean"`
+	// P50 godoc
+	P50 float64 `json:"p50" bson:"p50"`
+	// P90 godoc
+	P90 float64 `json:"p90" bson:"p90"`
+	// P95 godoc
+	P95 float64 `json:"p95" bson:"p95"`
+	// P99 godoc
+	P99 float64 `json:"p99" bson:"p99"`
+	// Histogram godoc
+	Histogram []GetStatisticsResponseHistogramBucket `json:"histogram" bson:"histogram"`
+}
+
+// GetStatisticsResponseHistogramBucket godoc
+type GetStatisticsResponseHistogramBucket struct {
+	// Le is the upper bound of the bucket in seconds, or +Inf for the overflow bucket
+	Le string `json:"le" bson:"le"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+}
+
+// ToResponse converts the duration statistics into their API representation
+func (d *DurationStatistics) ToResponse(durationType string) GetStatisticsResponseDuration {
+	result := GetStatisticsResponseDuration{
+		Type:      durationType,
+		Count:     d.Count,
+		Sum:       d.Sum,
+		Min:       d.Min,
+		Max:       d.Max,
+		Mean:      d.Mean(),
+		P50:       d.Percentile(50),
+		P90:       d.Percentile(90),
+		P95:       d.Percentile(95),
+		P99:       d.Percentile(99),
+		Histogram: []GetStatisticsResponseHistogramBucket{},
+	}
+	for index, count := range d.Histogram {
+		le := "+Inf"
+		if index < len(DurationHistogramBounds) {
+			le = strconv.FormatFloat(DurationHistogramBounds[index], 'f', -1, 64)
+		}
+		result.Histogram = append(result.Histogram, GetStatisticsResponseHistogramBucket{
+			Le:    le,
+			Count: count,
+		})
+	}
+	return result
+}
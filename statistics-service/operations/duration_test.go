@@ -0,0 +1,99 @@
+package operations
+
+import (
+	"github.com/go-test/deep"
+	"testing"
+	"time"
+)
+
+func TestDurationStatistics_Add(t *testing.T) {
+	d := NewDurationStatistics()
+	d.Add(3 * time.Second)
+	d.Add(45 * time.Second)
+	d.Add(48 * time.Hour)
+
+	want := &DurationStatistics{
+		Count:     3,
+		Sum:       3 + 45 + 48*3600,
+		Min:       3,
+		Max:       48 * 3600,
+		Histogram: []int{0, 1, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+	}
+	if diff := deep.Equal(d, want); len(diff) > 0 {
+		t.Error("DurationStatistics.Add(): did not get expected value")
+		for _, dd := range diff {
+			t.Log(dd)
+		}
+	}
+}
+
+func TestDurationStatistics_Merge(t *testing.T) {
+	first := NewDurationStatistics()
+	first.Add(2 * time.Second)
+	second := NewDurationStatistics()
+	second.Add(20 * time.Second)
+	second.Add(1 * time.Second)
+
+	merged := &DurationStatistics{}
+	merged.Merge(first)
+	merged.Merge(second)
+	merged.Merge(nil)
+
+	want := &DurationStatistics{
+		Count:     3,
+		Sum:       23,
+		Min:       1,
+		Max:       20,
+		Histogram: []int{1, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	if diff := deep.Equal(merged, want); len(diff) > 0 {
+		t.Error("DurationStatistics.Merge(): did not get expected value")
+		for _, dd := range diff {
+			t.Log(dd)
+		}
+	}
+}
+
+func TestDurationStatistics_Percentile(t *testing.T) {
+	d := NewDurationStatistics()
+	for i := 0; i < 90; i++ {
+		d.Add(20 * time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		d.Add(500 * time.Second)
+	}
+
+	tests := []struct {
+		name       string
+		percentile float64
+		wantMin    float64
+		wantMax    float64
+	}{
+		{
+			name:       "median",
+			percentile: 50,
+			wantMin:    10,
+			wantMax:    30,
+		},
+		{
+			name:       "p99",
+			percentile: 99,
+			wantMin:    300,
+			wantMax:    500,
+		},
+		{
+			name:       "p100",
+			percentile: 100,
+			wantMin:    500,
+			wantMax:    500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Percentile(tt.percentile)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("DurationStatistics.Percentile(%v) = %v, want value between %v and %v", tt.percentile, got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
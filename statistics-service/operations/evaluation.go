@@ -0,0 +1,188 @@
+package operations
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// evaluationScoreBuckets is the number of buckets used for the score distribution. Each bucket covers a range of 10 points
+const evaluationScoreBuckets = 10
+
+// EvaluationStatistics contains statistics about the quality gate evaluations of a service
+type EvaluationStatistics struct {
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+	// Results contains the number of evaluations per result (pass, warning, fail)
+	Results map[string]int `json:"results" bson:"results"`
+	// ScoreCount contains the number of evaluations that reported a score
+	ScoreCount int `json:"scoreCount" bson:"scoreCount"`
+	// ScoreSum godoc
+	ScoreSum float64 `json:"scoreSum" bson:"scoreSum"`
+	// ScoreDistribution contains the number of evaluations per score range (0-10, 10-20, ..., 90-100)
+	ScoreDistribution []int `json:"scoreDistribution" bson:"scoreDistribution"`
+}
+
+// NewEvaluationStatistics godoc
+func NewEvaluationStatistics() *EvaluationStatistics {
+	return &EvaluationStatistics{
+		Results:           map[string]int{},
+		ScoreDistribution: make([]int, evaluationScoreBuckets),
+	}
+}
+
+// Add adds an evaluation. If hasScore is false, the score is ignored
+func (e *EvaluationStatistics) Add(result string, score float64, hasScore bool) {
+	e.ensureInitialized()
+	e.Count = e.Count + 1
+	if result != "" {
+		result = strings.ToLower(result)
+		e.Results[result] = e.Results[result] + 1
+	}
+	if hasScore {
+		e.ScoreCount = e.ScoreCount + 1
+		e.ScoreSum = e.ScoreSum + score
+		bucket := int(math.Floor(score / (100 / evaluationScoreBuckets)))
+		if bucket < 0 {
+			bucket = 0
+		} else if bucket >= evaluationScoreBuckets {
+			bucket = evaluationScoreBuckets - 1
+		}
+		e.ScoreDistribution[bucket] = e.ScoreDistribution[bucket] + 1
+	}
+}
+
+// Merge adds the evaluations of the given statistics
+func (e *EvaluationStatistics) Merge(other *EvaluationStatistics) {
+	if other == nil {
+		return
+	}
+	e.ensureInitialized()
+	e.Count = e.Count + other.Count
+	for result, count := range other.Results {
+		e.Results[result] = e.Results[result] + count
+	}
+	e.ScoreCount = e.ScoreCount + other.ScoreCount
+	e.ScoreSum = e.ScoreSum + other.ScoreSum
+	for index, count := range other.ScoreDistribution {
+		if index < evaluationScoreBuckets {
+			e.ScoreDistribution[index] = e.ScoreDistribution[index] + count
+		}
+	}
+}
+
+func (e *EvaluationStatistics) ensureInitialized() {
+	if e.Results == nil {
+		e.Results = map[string]int{}
+	}
+	if len(e.ScoreDistribution) != evaluationScoreBuckets {
+		distribution := make([]int, evaluationScoreBuckets)
+		copy(distribution, e.ScoreDistribution)
+		e.ScoreDistribution = distribution
+	}
+}
+
+// GetStatisticsResponseEvaluations godoc
+type GetStatisticsResponseEvaluations struct {
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+	// Pass godoc
+	Pass int `json:"pass" bson:"pass"`
+	// Warning godoc
+	Warning int `json:"warning" bson:"warning"`
+	// Fail godoc
+	Fail int `json:"fail" bson:"fail"`
+	// PassRatio godoc
+	PassRatio float64 `json:"passRatio" bson:"passRatio"`
+	// WarningRatio godoc
+	WarningRatio float64 `json:"warningRatio" bson:"warningRatio"`
+	// FailRatio godoc
+	FailRatio float64 `json:"failRatio" bson:"failRatio"`
+	// AverageScore godoc
+	AverageScore float64 `json:"averageScore" bson:"averageScore"`
+	// ScoreDistribution godoc
+	ScoreDistribution []GetStatisticsResponseScoreBucket `json:"scoreDistribution" bson:"scoreDistribution"`
+}
+
+// GetStatisticsResponseScoreBucket godoc
+type GetStatisticsResponseScoreBucket struct {
+	// From godoc
+	From int `json:"from" bson:"from"`
+	// To godoc
+	To int `json:"to" bson:"to"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+}
+
+// ToResponse converts the evaluation statistics into their API representation
+func (e *EvaluationStatistics) ToResponse() *GetStatisticsResponseEvaluations {
+	if e == nil {
+		return nil
+	}
+	result := &GetStatisticsResponseEvaluations{
+		Count:             e.Count,
+		Pass:              e.Results["pass"],
+		Warning:           e.Results["warning"],
+		Fail:              e.Results["fail"],
+		ScoreDistribution: []GetStatisticsResponseScoreBucket{},
+	}
+	if e.Count > 0 {
+		result.PassRatio = float64(result.Pass) / float64(e.Count)
+		result.WarningRatio = float64(result.Warning) / float64(e.Count)
+		result.FailRatio = float64(result.Fail) / float64(e.Count)
+	}
+	if e.ScoreCount > 0 {
+		result.AverageScore = e.ScoreSum / float64(e.ScoreCount)
+	}
+	bucketSize := 100 / evaluationScoreBuckets
+	for index, count := range e.ScoreDistribution {
+		result.ScoreDistribution = append(result.ScoreDistribution, GetStatisticsResponseScoreBucket{
+			From:  index * bucketSize,
+			To:    (index + 1) * bucketSize,
+			Count: count,
+		})
+	}
+	return result
+}
+
+// GetEvaluationsResponse godoc
+type GetEvaluationsResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// Evaluations godoc
+	Evaluations *GetStatisticsResponseEvaluations `json:"evaluations" bson:"evaluations"`
+	// Projects godoc
+	Projects []GetEvaluationsResponseProject `json:"projects" bson:"projects"`
+}
+
+// GetEvaluationsResponseProject godoc
+type GetEvaluationsResponseProject struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Evaluations godoc
+	Evaluations *GetStatisticsResponseEvaluations `json:"evaluations" bson:"evaluations"`
+	// Services contains the evaluations of the project's services across all stages
+	Services []GetEvaluationsResponseService `json:"services" bson:"services"`
+	// Stages godoc
+	Stages []GetEvaluationsResponseStage `json:"stages" bson:"stages"`
+}
+
+// GetEvaluationsResponseStage godoc
+type GetEvaluationsResponseStage struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Evaluations godoc
+	Evaluations *GetStatisticsResponseEvaluations `json:"evaluations" bson:"evaluations"`
+	// Services godoc
+	Services []GetEvaluationsResponseService `json:"services" bson:"services"`
+}
+
+// GetEvaluationsResponseService godoc
+type GetEvaluationsResponseService struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Evaluations godoc
+	Evaluations *GetStatisticsResponseEvaluations `json:"evaluations" bson:"evaluations"`
+}
@@ -0,0 +1,51 @@
+package operations
+
+import (
+	"github.com/go-test/deep"
+	"testing"
+)
+
+func TestEvaluationStatistics_ToResponse(t *testing.T) {
+	first := NewEvaluationStatistics()
+	first.Add("pass", 95, true)
+	first.Add("Warning", 70, true)
+
+	second := &EvaluationStatistics{}
+	second.Add("fail", 100, true)
+	second.Add("fail", 0, false)
+
+	merged := NewEvaluationStatistics()
+	merged.Merge(first)
+	merged.Merge(second)
+	merged.Merge(nil)
+
+	want := &GetStatisticsResponseEvaluations{
+		Count:        4,
+		Pass:         1,
+		Warning:      1,
+		Fail:         2,
+		PassRatio:    0.25,
+		WarningRatio: 0.25,
+		FailRatio:    0.5,
+		AverageScore: 265.0 / 3,
+		ScoreDistribution: []GetStatisticsResponseScoreBucket{
+			{From: 0, To: 10, Count: 0},
+			{From: 10, To: 20, Count: 0},
+			{From: 20, To: 30, Count: 0},
+			{From: 30, To: 40, Count: 0},
+			{From: 40, To: 50, Count: 0},
+			{From: 50, To: 60, Count: 0},
+			{From: 60, To: 70, Count: 0},
+			{From: 70, To: 80, Count: 1},
+			{From: 80, To: 90, Count: 0},
+			{From: 90, To: 100, Count: 2},
+		},
+	}
+
+	if diff := deep.Equal(merged.ToResponse(), want); len(diff) > 0 {
+		t.Error("EvaluationStatistics.ToResponse(): did not get expected value")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+}
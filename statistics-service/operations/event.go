@@ -1,5 +1,7 @@
 package operations
 
+import "time"
+
 // Event godoc
 type Event struct {
 	Contenttype    string      `json:"contenttype,omitempty"`
@@ -14,7 +16,52 @@ type Event struct {
 	Type           string      `json:"type"`
 }
 
+// KeptnBase godoc
 type KeptnBase struct {
 	Project string `json:"project"`
+	Stage   string `json:"stage,omitempty"`
 	Service string `json:"service"`
+	Result  string `json:"result,omitempty"`
+	Status  string `json:"status,omitempty"`
+	// Evaluation contains the result of an evaluation.finished event
+	Evaluation *EvaluationDetails `json:"evaluation,omitempty"`
+	// EvaluationDetails contains the result of an evaluation-done event (Keptn < 0.8)
+	EvaluationDetails *EvaluationDetails `json:"evaluationdetails,omitempty"`
+	// State contains the state of a problem event (e.g. OPEN, RESOLVED or CLOSED)
+	State string `json:"State,omitempty"`
+	// ProblemID contains the ID of the problem a problem event belongs to
+	ProblemID string `json:"ProblemID,omitempty"`
+	// Action contains the remediation action of an action.triggered or action.finished event
+	Action *ActionDetails `json:"action,omitempty"`
+	// Approval contains the approval strategy of an approval.triggered event or, for Keptn < 0.8, the decision of an approval.finished event
+	Approval *ApprovalDetails `json:"approval,omitempty"`
+	// Labels contains the labels of the event, e.g. the team or the build number
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ApprovalDetails contains the approval strategy of an approval.triggered event, or the decision of an approval.finished event (Keptn < 0.8)
+type ApprovalDetails struct {
+	Pass    string `json:"pass,omitempty"`
+	Warning string `json:"warning,omitempty"`
+	Result  string `json:"result,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// ActionDetails godoc
+type ActionDetails struct {
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action,omitempty"`
+	Result string `json:"result,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// EvaluationDetails godoc
+type EvaluationDetails struct {
+	Result string   `json:"result,omitempty"`
+	Score  *float64 `json:"score,omitempty"`
+}
+
+// GetTime returns the time at which the event has been created
+func (e Event) GetTime() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, e.Time)
 }
@@ -0,0 +1,155 @@
+package operations
+
+import (
+	"strings"
+)
+
+// EventGenerationLegacy denotes events of Keptn < 0.8
+const EventGenerationLegacy = "legacy"
+
+// EventGenerationNextGen denotes events of Keptn >= 0.8
+const EventGenerationNextGen = "next-gen"
+
+const nextGenEventTypePrefix = "sh.keptn.event."
+
+// nextGenEventPhases contains the suffixes of next-gen event types
+var nextGenEventPhases = []string{".triggered", ".started", ".status.changed", ".finished", ".invalidated"}
+
+// legacyTaskNames maps the event types of Keptn < 0.8 to the task names used by Keptn >= 0.8
+var legacyTaskNames = map[string]string{
+	"sh.keptn.event.configuration.change":  "configuration-change",
+	"sh.keptn.events.deployment-finished":  "deployment",
+	"sh.keptn.events.tests-finished":       "test",
+	"sh.keptn.event.start-evaluation":      "evaluation",
+	"sh.keptn.events.evaluation-done":      "evaluation",
+	"sh.keptn.internal.event.get-sli":      "get-sli",
+	"sh.keptn.internal.event.get-sli.done": "get-sli",
+	"sh.keptn.event.monitoring.configure":  "configure-monitoring",
+	"sh.keptn.event.problem.open":          "problem",
+	"sh.keptn.events.problem":              "problem",
+}
+
+// legacyEventTypePrefixes contains the prefixes that are removed from legacy event types that have no known task name
+var legacyEventTypePrefixes = []string{"sh.keptn.internal.event.", "sh.keptn.events.", "sh.keptn.event."}
+
+// legacyEventTypeSuffixes contains the suffixes that are removed from legacy event types that have no known task name
+var legacyEventTypeSuffixes = []string{"-finished", "-done", ".done"}
+
+// GetEventGeneration determines whether an event has been sent by Keptn < 0.8 (legacy) or Keptn >= 0.8 (next-gen).
+// Keptn >= 0.8 uses CloudEvents 1.0, while earlier versions use CloudEvents 0.x. If the specversion is not set, the naming scheme of the event type is used
+func (e Event) GetEventGeneration() string {
+	if e.Specversion != "" {
+		if strings.HasPrefix(e.Specversion, "0.") {
+			return EventGenerationLegacy
+		}
+		return EventGenerationNextGen
+	}
+	if isNextGenEventType(e.Type) {
+		return EventGenerationNextGen
+	}
+	return EventGenerationLegacy
+}
+
+func isNextGenEventType(eventType string) bool {
+	_, ok := ParseEventType(eventType)
+	return ok
+}
+
+// KeptnEventType contains the parts of an event type of Keptn >= 0.8.
+// Task events have the format sh.keptn.event.<task>.<phase>, e.g. sh.keptn.event.deployment.started, and
+// sequence events have the format sh.keptn.event.<stage>.<sequence>.<phase>, e.g. sh.keptn.event.dev.delivery.finished
+type KeptnEventType struct {
+	// Stage is only set for sequence events
+	Stage string
+	// Sequence is only set for sequence events
+	Sequence string
+	// Task is only set for task events
+	Task string
+	// Phase contains the phase of the event, i.e. triggered, started, status.changed, finished or invalidated
+	Phase string
+}
+
+// ParseEventType splits an event type of Keptn >= 0.8 into its parts. If the event type does not follow the naming scheme of Keptn >= 0.8, false is returned
+func ParseEventType(eventType string) (KeptnEventType, bool) {
+	if !strings.HasPrefix(eventType, nextGenEventTypePrefix) {
+		return KeptnEventType{}, false
+	}
+	name := strings.TrimPrefix(eventType, nextGenEventTypePrefix)
+	for _, phase := range nextGenEventPhases {
+		if !strings.HasSuffix(name, phase) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(name, phase), ".")
+		for _, part := range parts {
+			if part == "" {
+				return KeptnEventType{}, false
+			}
+		}
+		result := KeptnEventType{
+			Phase: strings.TrimPrefix(phase, "."),
+		}
+		switch len(parts) {
+		case 1:
+			result.Task = parts[0]
+		case 2:
+			result.Stage = parts[0]
+			result.Sequence = parts[1]
+		default:
+			return KeptnEventType{}, false
+		}
+		return result, true
+	}
+	return KeptnEventType{}, false
+}
+
+// IsSequenceEvent returns true if the event type belongs to a sequence rather than to a task
+func (t KeptnEventType) IsSequenceEvent() bool {
+	return t.Sequence != ""
+}
+
+// GetName returns the name of the sequence or of the task
+func (t KeptnEventType) GetName() string {
+	if t.IsSequenceEvent() {
+		return t.Sequence
+	}
+	return t.Task
+}
+
+// GetTaskName returns the logical task name of an event type, so that events of legacy and next-gen Keptn versions can be compared,
+// e.g. 'deployment' for both sh.keptn.event.deployment.finished and sh.keptn.events.deployment-finished.
+// For sequence events, the name of the sequence is returned, e.g. 'delivery' for sh.keptn.event.dev.delivery.finished.
+// Task names are returned unchanged, i.e. GetTaskName(GetTaskName(eventType)) == GetTaskName(eventType)
+func GetTaskName(eventType string) string {
+	if taskName, ok := legacyTaskNames[eventType]; ok {
+		return taskName
+	}
+	if keptnEventType, ok := ParseEventType(eventType); ok {
+		return keptnEventType.GetName()
+	}
+	taskName := eventType
+	for _, prefix := range legacyEventTypePrefixes {
+		if strings.HasPrefix(taskName, prefix) {
+			taskName = strings.TrimPrefix(taskName, prefix)
+			break
+		}
+	}
+	for _, suffix := range legacyEventTypeSuffixes {
+		if strings.HasSuffix(taskName, suffix) {
+			taskName = strings.TrimSuffix(taskName, suffix)
+			break
+		}
+	}
+	return taskName
+}
+
+// normalizeTaskName returns the task name of a key of the statistics stored by previous versions of the service, which counted
+// executions, sequences, durations and task results per event type, e.g. sh.keptn.events.deployment-finished, or per event type
+// without its phase, e.g. sh.keptn.event.deployment or sh.keptn.event.dev.delivery
+func normalizeTaskName(key string) string {
+	if _, ok := legacyTaskNames[key]; !ok && !isNextGenEventType(key) {
+		if keptnEventType, ok := ParseEventType(key + ".finished"); ok {
+			return keptnEventType.GetName()
+		}
+	}
+	return GetTaskName(key)
+}
@@ -0,0 +1,140 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestEvent_GetEventGeneration(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			name:  "next-gen specversion",
+			event: Event{Type: "sh.keptn.event.approval.triggered", Specversion: "1.0"},
+			want:  EventGenerationNextGen,
+		},
+		{
+			name:  "legacy specversion",
+			event: Event{Type: "sh.keptn.event.approval.triggered", Specversion: "0.2"},
+			want:  EventGenerationLegacy,
+		},
+		{
+			name:  "next-gen event type",
+			event: Event{Type: "sh.keptn.event.dev.delivery.finished"},
+			want:  EventGenerationNextGen,
+		},
+		{
+			name:  "legacy event type",
+			event: Event{Type: "sh.keptn.events.deployment-finished"},
+			want:  EventGenerationLegacy,
+		},
+		{
+			name:  "legacy configuration change",
+			event: Event{Type: "sh.keptn.event.configuration.change"},
+			want:  EventGenerationLegacy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.GetEventGeneration(); got != tt.want {
+				t.Errorf("GetEventGeneration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTaskName(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      string
+	}{
+		{eventType: "sh.keptn.event.deployment.finished", want: "deployment"},
+		{eventType: "sh.keptn.events.deployment-finished", want: "deployment"},
+		{eventType: "sh.keptn.event.test.started", want: "test"},
+		{eventType: "sh.keptn.events.tests-finished", want: "test"},
+		{eventType: "sh.keptn.event.evaluation.status.changed", want: "evaluation"},
+		{eventType: "sh.keptn.event.start-evaluation", want: "evaluation"},
+		{eventType: "sh.keptn.events.evaluation-done", want: "evaluation"},
+		{eventType: "sh.keptn.internal.event.get-sli.done", want: "get-sli"},
+		{eventType: "sh.keptn.event.dev.delivery.triggered", want: "delivery"},
+		{eventType: "sh.keptn.internal.event.project.create", want: "project.create"},
+		{eventType: "sh.keptn.event.deployment", want: "deployment"},
+		{eventType: "deployment", want: "deployment"},
+		{eventType: "my-type", want: "my-type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			if got := GetTaskName(tt.eventType); got != tt.want {
+				t.Errorf("GetTaskName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_normalizeTaskName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "sh.keptn.event.deployment", want: "deployment"},
+		{key: "sh.keptn.event.dev.delivery", want: "delivery"},
+		{key: "sh.keptn.events.deployment-finished", want: "deployment"},
+		{key: "sh.keptn.event.configuration.change", want: "configuration-change"},
+		{key: "sh.keptn.event.approval.triggered", want: "approval"},
+		{key: "deployment", want: "deployment"},
+		{key: "my-type", want: "my-type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := normalizeTaskName(tt.key); got != tt.want {
+				t.Errorf("normalizeTaskName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEventType(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      KeptnEventType
+		wantOk    bool
+	}{
+		{
+			eventType: "sh.keptn.event.production.delivery.finished",
+			want:      KeptnEventType{Stage: "production", Sequence: "delivery", Phase: "finished"},
+			wantOk:    true,
+		},
+		{
+			eventType: "sh.keptn.event.deployment.started",
+			want:      KeptnEventType{Task: "deployment", Phase: "started"},
+			wantOk:    true,
+		},
+		{
+			eventType: "sh.keptn.event.get-sli.status.changed",
+			want:      KeptnEventType{Task: "get-sli", Phase: "status.changed"},
+			wantOk:    true,
+		},
+		{
+			eventType: "sh.keptn.events.deployment-finished",
+		},
+		{
+			eventType: "sh.keptn.event.problem.open",
+		},
+		{
+			eventType: "sh.keptn.event.a.b.c.finished",
+		},
+		{
+			eventType: "sh.keptn.event..finished",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			got, ok := ParseEventType(tt.eventType)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("ParseEventType() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
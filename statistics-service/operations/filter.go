@@ -0,0 +1,228 @@
+package operations
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CompileWildcardPattern compiles a pattern that may contain '*' as a wildcard for any sequence of characters
+func CompileWildcardPattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for index, part := range parts {
+		parts[index] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// NameFilter matches names against a list of wildcard patterns. An empty filter matches all names
+type NameFilter []*regexp.Regexp
+
+// NewNameFilter creates a filter from a comma-separated list of wildcard patterns, e.g. 'sockshop,test-*'
+func NewNameFilter(patterns string) NameFilter {
+	result := NameFilter{}
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			result = append(result, CompileWildcardPattern(pattern))
+		}
+	}
+	return result
+}
+
+// Matches returns true if the filter is empty or one of the names matches one of the patterns
+func (f NameFilter) Matches(names ...string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, pattern := range f {
+		for _, name := range names {
+			if pattern.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StatisticsFilter restricts the statistics to the given projects, services, Keptn services and event types
+type StatisticsFilter struct {
+	Projects      NameFilter
+	Services      NameFilter
+	KeptnServices NameFilter
+	// EventTypes are matched against the event types as well as against their task names, e.g. 'deployment' matches sh.keptn.event.deployment.finished
+	EventTypes NameFilter
+}
+
+// NewStatisticsFilter creates the filter for the query parameters
+func NewStatisticsFilter(params GetStatisticsParams) StatisticsFilter {
+	return StatisticsFilter{
+		Projects:      NewNameFilter(params.Project),
+		Services:      NewNameFilter(params.Service),
+		KeptnServices: NewNameFilter(params.KeptnService),
+		EventTypes:    NewNameFilter(params.EventType),
+	}
+}
+
+// IsEmpty returns true if the filter does not restrict the statistics
+func (f StatisticsFilter) IsEmpty() bool {
+	return len(f.Projects) == 0 && len(f.Services) == 0 && len(f.KeptnServices) == 0 && len(f.EventTypes) == 0
+}
+
+func (f StatisticsFilter) matchesEventType(eventType string) bool {
+	return f.EventTypes.Matches(eventType, GetTaskName(eventType))
+}
+
+// restrictsServices returns true if the filter may remove services from the statistics
+func (f StatisticsFilter) restrictsServices() bool {
+	return len(f.Services) > 0 || len(f.KeptnServices) > 0 || len(f.EventTypes) > 0
+}
+
+// Filter returns a copy of the statistics that only contains the projects, services, Keptn services and event types matching the filter.
+// Projects, stages and services without any matching statistics are omitted. The original statistics are not modified
+func (s Statistics) Filter(filter StatisticsFilter) Statistics {
+	if filter.IsEmpty() {
+		return s
+	}
+	result := s
+	result.Projects = map[string]*Project{}
+	for projectName, project := range s.Projects {
+		if !filter.Projects.Matches(projectName) {
+			continue
+		}
+		newProject := &Project{
+			Name:     project.Name,
+			Services: filter.filterServices(project.Services),
+		}
+		for stageName, stage := range project.Stages {
+			services := filter.filterServices(stage.Services)
+			if len(services) == 0 && filter.restrictsServices() {
+				continue
+			}
+			if newProject.Stages == nil {
+				newProject.Stages = map[string]*Stage{}
+			}
+			newProject.Stages[stageName] = &Stage{
+				Name:     stage.Name,
+				Services: services,
+			}
+		}
+		if len(newProject.Services) == 0 && len(newProject.Stages) == 0 && filter.restrictsServices() {
+			continue
+		}
+		for keptnServiceName, keptnService := range project.KeptnServices {
+			if filter.KeptnServices.Matches(keptnServiceName) {
+				if newProject.KeptnServices == nil {
+					newProject.KeptnServices = map[string]*KeptnServiceInventory{}
+				}
+				newProject.KeptnServices[keptnServiceName] = keptnService
+			}
+		}
+		result.Projects[projectName] = newProject
+	}
+	return result
+}
+
+// filterServices returns the matching services. Services without executions of a matching Keptn service or without events of a matching type are omitted
+func (f StatisticsFilter) filterServices(services map[string]*Service) map[string]*Service {
+	result := map[string]*Service{}
+	for serviceName, service := range services {
+		if !f.Services.Matches(serviceName) {
+			continue
+		}
+		// the remaining properties are not modified, so they can be shared with the original service
+		newService := *service
+		newService.KeptnServiceExecutions = map[string]*KeptnService{}
+		for keptnServiceName, keptnService := range service.KeptnServiceExecutions {
+			if !f.KeptnServices.Matches(keptnServiceName) {
+				continue
+			}
+			if filtered := f.filterKeptnService(keptnService); len(f.EventTypes) == 0 || !filtered.isEmpty() {
+				newService.KeptnServiceExecutions[keptnServiceName] = filtered
+			}
+		}
+		if len(f.KeptnServices) > 0 && len(newService.KeptnServiceExecutions) == 0 {
+			continue
+		}
+		if len(f.EventTypes) > 0 {
+			f.filterEventTypes(&newService)
+			if len(newService.Events) == 0 && len(newService.KeptnServiceExecutions) == 0 && len(newService.ExecutedSequencesPerType) == 0 {
+				continue
+			}
+		}
+		result[serviceName] = &newService
+	}
+	return result
+}
+
+// filterEventTypes restricts the counts of the service that are kept per event type, task or sequence to the matching ones.
+// Evaluations are only kept if evaluations match, and labels are omitted, since they are not counted per event type
+func (f StatisticsFilter) filterEventTypes(service *Service) {
+	service.Events = f.filterCounts(service.Events)
+	service.ExecutedSequencesPerType = f.filterCounts(service.ExecutedSequencesPerType)
+	service.ExecutedSequences = 0
+	for _, count := range service.ExecutedSequencesPerType {
+		service.ExecutedSequences = service.ExecutedSequences + count
+	}
+	if service.SequenceDurations != nil {
+		sequenceDurations := map[string]*DurationStatistics{}
+		for sequenceType, durations := range service.SequenceDurations {
+			if f.matchesEventType(sequenceType) {
+				sequenceDurations[sequenceType] = durations
+			}
+		}
+		service.SequenceDurations = sequenceDurations
+	}
+	if service.Triggers != nil {
+		triggers := map[string]map[string]int{}
+		for triggerType, sources := range service.Triggers {
+			if f.matchesEventType(triggerType) {
+				triggers[triggerType] = sources
+			}
+		}
+		service.Triggers = triggers
+	}
+	if !f.matchesEventType("sh.keptn.event.evaluation.finished") && !f.matchesEventType("sh.keptn.events.evaluation-done") {
+		service.Evaluations = nil
+	}
+	service.Labels = nil
+}
+
+func (f StatisticsFilter) filterCounts(counts map[string]int) map[string]int {
+	result := map[string]int{}
+	for eventType, count := range counts {
+		if f.matchesEventType(eventType) {
+			result[eventType] = count
+		}
+	}
+	return result
+}
+
+func (f StatisticsFilter) filterKeptnService(keptnService *KeptnService) *KeptnService {
+	if len(f.EventTypes) == 0 {
+		return keptnService
+	}
+	result := &KeptnService{
+		Name:       keptnService.Name,
+		Executions: f.filterCounts(keptnService.Executions),
+	}
+	for eventType, durations := range keptnService.Durations {
+		if f.matchesEventType(eventType) {
+			if result.Durations == nil {
+				result.Durations = map[string]*DurationStatistics{}
+			}
+			result.Durations[eventType] = durations
+		}
+	}
+	for eventType, taskResults := range keptnService.TaskResults {
+		if f.matchesEventType(eventType) {
+			if result.TaskResults == nil {
+				result.TaskResults = map[string]*TaskResults{}
+			}
+			result.TaskResults[eventType] = taskResults
+		}
+	}
+	return result
+}
+
+func (k *KeptnService) isEmpty() bool {
+	return len(k.Executions) == 0 && len(k.Durations) == 0 && len(k.TaskResults) == 0
+}
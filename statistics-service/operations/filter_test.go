@@ -0,0 +1,109 @@
+package operations
+
+import (
+	"github.com/go-test/deep"
+	"testing"
+	"time"
+)
+
+func TestNameFilter_Matches(t *testing.T) {
+	filter := NewNameFilter("sockshop, test-*,")
+	if !filter.Matches("sockshop") || !filter.Matches("test-42") || filter.Matches("podtatohead") {
+		t.Errorf("Matches(): unexpected result for filter %v", filter)
+	}
+	if !NewNameFilter("").Matches("anything") {
+		t.Error("Matches(): an empty filter must match all names")
+	}
+}
+
+func TestStatistics_Filter(t *testing.T) {
+	statistics := Statistics{}
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.deployment.finished", 1)
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.test.finished", 1)
+	statistics.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "helm-service", "deployment", 1)
+	statistics.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "jmeter-service", "test", 1)
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "orders", "sh.keptn.event.deployment.finished", 1)
+	statistics.IncreaseEventTypeCount("test-project", "", "carts", "sh.keptn.event.deployment.finished", 1)
+
+	statistics = statistics.WithProjectTotals()
+
+	got := statistics.Filter(NewStatisticsFilter(GetStatisticsParams{
+		Project:      "sock*",
+		Service:      "carts",
+		KeptnService: "*-service",
+		EventType:    "deployment",
+	}))
+
+	if len(got.Projects) != 1 || len(got.Projects["sockshop"].Services) != 1 || len(got.Projects["sockshop"].Stages["dev"].Services) != 1 {
+		t.Fatalf("Filter(): unexpected projects and services: %v", got.Projects)
+	}
+	carts := got.Projects["sockshop"].Services["carts"]
+	if diff := deep.Equal(carts.Events, map[string]int{"sh.keptn.event.deployment.finished": 1}); len(diff) > 0 {
+		t.Errorf("Filter(): unexpected events: %v", carts.Events)
+	}
+	if carts.KeptnServiceExecutions["helm-service"].Executions["deployment"] != 1 || carts.KeptnServiceExecutions["jmeter-service"] != nil {
+		t.Errorf("Filter(): unexpected Keptn service executions: %v", carts.KeptnServiceExecutions)
+	}
+
+	original := statistics.Projects["sockshop"].Services["carts"]
+	if len(original.Events) != 2 || len(original.KeptnServiceExecutions["jmeter-service"].Executions) != 1 {
+		t.Error("Filter(): the original statistics have been modified")
+	}
+}
+
+func TestStatistics_FilterOmitsEmptyEntries(t *testing.T) {
+	statistics := Statistics{}
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.deployment.finished", 1)
+	statistics.IncreaseEventTypeCount("sockshop", "production", "orders", "sh.keptn.event.test.finished", 1)
+	statistics.IncreaseEventTypeCount("podtato", "dev", "helloservice", "sh.keptn.event.test.finished", 1)
+	statistics = statistics.WithProjectTotals()
+
+	got := statistics.Filter(NewStatisticsFilter(GetStatisticsParams{EventType: "deployment"}))
+
+	if len(got.Projects) != 1 || got.Projects["sockshop"] == nil {
+		t.Fatalf("Filter(): want only the project with matching events, got %v", got.Projects)
+	}
+	sockshop := got.Projects["sockshop"]
+	if len(sockshop.Services) != 1 || sockshop.Services["carts"] == nil || len(sockshop.Stages) != 1 || sockshop.Stages["dev"] == nil {
+		t.Errorf("Filter(): want only the service and stage with matching events, got %v and %v", sockshop.Services, sockshop.Stages)
+	}
+
+	got = statistics.Filter(NewStatisticsFilter(GetStatisticsParams{Service: "orders"}))
+	if len(got.Projects) != 1 || len(got.Projects["sockshop"].Stages) != 1 || got.Projects["sockshop"].Stages["production"] == nil {
+		t.Errorf("Filter(): want only the project and stage of the matching service, got %v", got.Projects)
+	}
+}
+
+func TestStatistics_FilterAppliesEventTypesToAllCounts(t *testing.T) {
+	statistics := Statistics{}
+	statistics.IncreaseEventTypeCount("sockshop", "", "carts", "sh.keptn.event.dev.delivery.finished", 1)
+	statistics.IncreaseExecutedSequencesCount("sockshop", "", "carts", 2)
+	statistics.IncreaseExecutedSequenceCountForType("sockshop", "", "carts", "delivery", 1)
+	statistics.IncreaseExecutedSequenceCountForType("sockshop", "", "carts", "evaluation", 1)
+	statistics.AddSequenceDuration("sockshop", "", "carts", "delivery", time.Minute)
+	statistics.AddSequenceDuration("sockshop", "", "carts", "evaluation", time.Minute)
+	statistics.IncreaseTriggerCount("sockshop", "", "carts", "delivery", "cli", 1)
+	statistics.IncreaseTriggerCount("sockshop", "", "carts", "evaluation", "api", 1)
+	statistics.AddEvaluation("sockshop", "", "carts", "pass", 90, true)
+	statistics.Projects["sockshop"].Services["carts"].Labels = map[string]map[string]*LabelStatistics{"team": {"a": {Events: 1}}}
+
+	carts := statistics.Filter(NewStatisticsFilter(GetStatisticsParams{EventType: "delivery"})).Projects["sockshop"].Services["carts"]
+
+	if diff := deep.Equal(carts.ExecutedSequencesPerType, map[string]int{"delivery": 1}); len(diff) > 0 || carts.ExecutedSequences != 1 {
+		t.Errorf("Filter(): unexpected executed sequences: %d %v", carts.ExecutedSequences, carts.ExecutedSequencesPerType)
+	}
+	if len(carts.SequenceDurations) != 1 || carts.SequenceDurations["delivery"] == nil {
+		t.Errorf("Filter(): unexpected sequence durations: %v", carts.SequenceDurations)
+	}
+	if len(carts.Triggers) != 1 || carts.Triggers["delivery"] == nil {
+		t.Errorf("Filter(): unexpected triggers: %v", carts.Triggers)
+	}
+	if carts.Evaluations != nil || carts.Labels != nil {
+		t.Errorf("Filter(): want the evaluations and labels to be omitted, got %v and %v", carts.Evaluations, carts.Labels)
+	}
+
+	original := statistics.Projects["sockshop"].Services["carts"]
+	if original.ExecutedSequences != 2 || len(original.Triggers) != 2 || original.Evaluations == nil || original.Labels == nil {
+		t.Error("Filter(): the original statistics have been modified")
+	}
+}
@@ -0,0 +1,61 @@
+package operations
+
+import (
+	"time"
+)
+
+// HealthStatusOK godoc
+const HealthStatusOK = "ok"
+
+// HealthStatusFailed godoc
+const HealthStatusFailed = "failed"
+
+// HealthCheck contains the result of a single check
+type HealthCheck struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Status is either ok or failed
+	Status string `json:"status" bson:"status"`
+	// Message describes why the check has failed
+	Message string `json:"message,omitempty" bson:"message,omitempty"`
+}
+
+// NewHealthCheck returns a failed check if an error is given, and a successful one otherwise
+func NewHealthCheck(name string, err error) HealthCheck {
+	if err != nil {
+		return HealthCheck{Name: name, Status: HealthStatusFailed, Message: err.Error()}
+	}
+	return HealthCheck{Name: name, Status: HealthStatusOK}
+}
+
+// HealthResponse godoc
+type HealthResponse struct {
+	// Status is failed if any of the checks has failed
+	Status string `json:"status" bson:"status"`
+	// Checks godoc
+	Checks []HealthCheck `json:"checks" bson:"checks"`
+	// BucketStart contains the time the in-memory bucket has been created
+	BucketStart time.Time `json:"bucketStart" bson:"bucketStart"`
+	// LastSuccessfulFlush contains the time the last bucket has been stored in the database. It is not set if no bucket has been stored since the start of the service
+	LastSuccessfulFlush *time.Time `json:"lastSuccessfulFlush,omitempty" bson:"lastSuccessfulFlush,omitempty"`
+	// LastFlushFailed is true if the last bucket could not be stored in the database
+	LastFlushFailed bool `json:"lastFlushFailed" bson:"lastFlushFailed"`
+	// LastFlushError godoc
+	LastFlushError string `json:"lastFlushError,omitempty" bson:"lastFlushError,omitempty"`
+}
+
+// AddCheck adds the result of a check and updates the overall status
+func (r *HealthResponse) AddCheck(check HealthCheck) {
+	r.Checks = append(r.Checks, check)
+	if r.Status == "" {
+		r.Status = HealthStatusOK
+	}
+	if check.Status != HealthStatusOK {
+		r.Status = HealthStatusFailed
+	}
+}
+
+// IsHealthy godoc
+func (r *HealthResponse) IsHealthy() bool {
+	return r.Status == HealthStatusOK
+}
@@ -0,0 +1,168 @@
+package operations
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// hyperLogLogPrecision determines the number of registers (2^precision) used by a HyperLogLog sketch.
+// A precision of 12 results in 4096 registers (4KiB) and a standard error of about 1.6%
+const hyperLogLogPrecision = 12
+
+const hyperLogLogRegisters = 1 << hyperLogLogPrecision
+
+// hyperLogLogSparseEntrySize is the size of a register in the sparse encoding: the index (2 bytes, big-endian) followed by the rank
+const hyperLogLogSparseEntrySize = 3
+
+// hyperLogLogSparseLimit is the number of registers up to which a sketch is kept in the sparse encoding (3KiB).
+// Most services only observe a few Keptn contexts per bucket, so their sketches only need a few bytes instead of 4KiB
+const hyperLogLogSparseLimit = hyperLogLogRegisters / 4
+
+// HyperLogLog is a mergeable sketch that estimates the number of distinct values that have been added to it.
+// Sketches start in the sparse encoding and are converted to the dense encoding once more than hyperLogLogSparseLimit registers are set
+type HyperLogLog struct {
+	// Registers contains the ranks of all registers in the dense encoding; it is empty as long as the sketch is sparse
+	Registers []byte `json:"registers,omitempty" bson:"registers,omitempty"`
+	// Sparse contains the registers that are set in the sparse encoding, ordered by their index
+	Sparse []byte `json:"sparse,omitempty" bson:"sparse,omitempty"`
+}
+
+// NewHyperLogLog creates an empty HyperLogLog sketch
+func NewHyperLogLog() *HyperLogLog {
+	return &HyperLogLog{}
+}
+
+// Add adds a value to the sketch
+func (h *HyperLogLog) Add(value string) {
+	hash := hashValue(value)
+	index := int(hash >> (64 - hyperLogLogPrecision))
+	rank := byte(bits.LeadingZeros64(hash<<hyperLogLogPrecision|(1<<(hyperLogLogPrecision-1))) + 1)
+	h.setRegister(index, rank)
+}
+
+// Merge merges the given sketch into this one. Afterwards, this sketch estimates the cardinality of the union of both sketches
+func (h *HyperLogLog) Merge(other *HyperLogLog) {
+	if other == nil {
+		return
+	}
+	if other.isDense() {
+		h.toDense()
+		for index, rank := range other.Registers {
+			if rank > h.Registers[index] {
+				h.Registers[index] = rank
+			}
+		}
+		return
+	}
+	for offset := 0; offset+hyperLogLogSparseEntrySize <= len(other.Sparse); offset += hyperLogLogSparseEntrySize {
+		index, rank := getSparseEntry(other.Sparse, offset)
+		if index < hyperLogLogRegisters {
+			h.setRegister(index, rank)
+		}
+	}
+}
+
+// Count returns the estimated number of distinct values added to the sketch
+func (h *HyperLogLog) Count() int {
+	if h == nil {
+		return 0
+	}
+	m := float64(hyperLogLogRegisters)
+	sum := 0.0
+	emptyRegisters := 0
+	for _, rank := range h.getRegisters() {
+		sum += 1.0 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			emptyRegisters++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	if estimate <= 2.5*m && emptyRegisters > 0 {
+		// use linear counting for small cardinalities
+		estimate = m * math.Log(m/float64(emptyRegisters))
+	}
+	return int(math.Round(estimate))
+}
+
+func (h *HyperLogLog) isDense() bool {
+	return len(h.Registers) == hyperLogLogRegisters
+}
+
+// setRegister raises the rank of the register with the given index, and converts the sketch to the dense encoding if the sparse one gets too large
+func (h *HyperLogLog) setRegister(index int, rank byte) {
+	if h.isDense() {
+		if rank > h.Registers[index] {
+			h.Registers[index] = rank
+		}
+		return
+	}
+	entries := len(h.Sparse) / hyperLogLogSparseEntrySize
+	position := sort.Search(entries, func(i int) bool {
+		entryIndex, _ := getSparseEntry(h.Sparse, i*hyperLogLogSparseEntrySize)
+		return entryIndex >= index
+	})
+	offset := position * hyperLogLogSparseEntrySize
+	if position < entries {
+		if entryIndex, entryRank := getSparseEntry(h.Sparse, offset); entryIndex == index {
+			if rank > entryRank {
+				h.Sparse[offset+2] = rank
+			}
+			return
+		}
+	}
+	if entries >= hyperLogLogSparseLimit {
+		h.toDense()
+		h.setRegister(index, rank)
+		return
+	}
+	sparse := make([]byte, 0, len(h.Sparse)+hyperLogLogSparseEntrySize)
+	sparse = append(sparse, h.Sparse[:offset]...)
+	sparse = append(sparse, byte(index>>8), byte(index), rank)
+	h.Sparse = append(sparse, h.Sparse[offset:]...)
+}
+
+// toDense converts the sketch to the dense encoding
+func (h *HyperLogLog) toDense() {
+	if h.isDense() {
+		return
+	}
+	h.Registers = h.getRegisters()
+	h.Sparse = nil
+}
+
+// getRegisters returns the ranks of all registers, regardless of the encoding of the sketch
+func (h *HyperLogLog) getRegisters() []byte {
+	if h.isDense() {
+		return h.Registers
+	}
+	registers := make([]byte, hyperLogLogRegisters)
+	for offset := 0; offset+hyperLogLogSparseEntrySize <= len(h.Sparse); offset += hyperLogLogSparseEntrySize {
+		index, rank := getSparseEntry(h.Sparse, offset)
+		if index < hyperLogLogRegisters && rank > registers[index] {
+			registers[index] = rank
+		}
+	}
+	return registers
+}
+
+func getSparseEntry(sparse []byte, offset int) (int, byte) {
+	return int(sparse[offset])<<8 | int(sparse[offset+1]), sparse[offset+2]
+}
+
+func hashValue(value string) uint64 {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(value))
+	hash := hasher.Sum64()
+
+	// FNV does not distribute similar inputs well enough, so we apply the murmur3 finalizer on top of it
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
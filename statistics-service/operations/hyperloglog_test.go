@@ -0,0 +1,115 @@
+package operations
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog_Count(t *testing.T) {
+	tests := []struct {
+		name           string
+		distinctValues int
+		repetitions    int
+	}{
+		{
+			name:           "empty sketch",
+			distinctValues: 0,
+			repetitions:    1,
+		},
+		{
+			name:           "single value added multiple times",
+			distinctValues: 1,
+			repetitions:    5,
+		},
+		{
+			name:           "small cardinality",
+			distinctValues: 100,
+			repetitions:    3,
+		},
+		{
+			name:           "large cardinality",
+			distinctValues: 50000,
+			repetitions:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHyperLogLog()
+			for r := 0; r < tt.repetitions; r++ {
+				for i := 0; i < tt.distinctValues; i++ {
+					h.Add(fmt.Sprintf("keptn-context-%d", i))
+				}
+			}
+
+			got := h.Count()
+			if !isWithinTolerance(got, tt.distinctValues) {
+				t.Errorf("HyperLogLog.Count() = %d, want %d", got, tt.distinctValues)
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	first := NewHyperLogLog()
+	second := NewHyperLogLog()
+
+	// the first sketch contains values 0-5999, the second one values 4000-9999
+	for i := 0; i < 6000; i++ {
+		first.Add(fmt.Sprintf("keptn-context-%d", i))
+	}
+	for i := 4000; i < 10000; i++ {
+		second.Add(fmt.Sprintf("keptn-context-%d", i))
+	}
+
+	merged := &HyperLogLog{}
+	merged.Merge(first)
+	merged.Merge(second)
+	merged.Merge(nil)
+
+	if got := merged.Count(); !isWithinTolerance(got, 10000) {
+		t.Errorf("HyperLogLog.Merge(): merged sketch estimated %d distinct values, want %d", got, 10000)
+	}
+}
+
+func TestHyperLogLog_CountNil(t *testing.T) {
+	var h *HyperLogLog
+	if got := h.Count(); got != 0 {
+		t.Errorf("HyperLogLog.Count() = %d, want 0", got)
+	}
+}
+
+func isWithinTolerance(got, want int) bool {
+	// allow an error of 5%, which is about three times the standard error of the sketch
+	return math.Abs(float64(got-want)) <= math.Max(1, 0.05*float64(want))
+}
+
+func TestHyperLogLog_Sparse(t *testing.T) {
+	h := NewHyperLogLog()
+	for i := 0; i < 10; i++ {
+		h.Add(fmt.Sprintf("keptn-context-%d", i))
+	}
+	if len(h.Registers) != 0 || len(h.Sparse) != 10*hyperLogLogSparseEntrySize {
+		t.Errorf("HyperLogLog.Add(): want a sparse sketch with 10 registers, got %d dense and %d sparse bytes", len(h.Registers), len(h.Sparse))
+	}
+	if got := h.Count(); got != 10 {
+		t.Errorf("HyperLogLog.Count() = %d, want 10", got)
+	}
+
+	dense := NewHyperLogLog()
+	for i := 0; i < 5000; i++ {
+		dense.Add(fmt.Sprintf("keptn-context-%d", i))
+	}
+	if len(dense.Registers) != hyperLogLogRegisters || len(dense.Sparse) != 0 {
+		t.Errorf("HyperLogLog.Add(): want a dense sketch, got %d dense and %d sparse bytes", len(dense.Registers), len(dense.Sparse))
+	}
+
+	// merging a sparse sketch into a dense one and vice versa results in the same estimate
+	merged := NewHyperLogLog()
+	merged.Merge(h)
+	merged.Merge(dense)
+	dense.Merge(h)
+	if got, want := merged.Count(), dense.Count(); got != want {
+		t.Errorf("HyperLogLog.Merge(): got %d distinct values, want %d", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package operations
+
+import (
+	"strings"
+	"time"
+)
+
+// UnknownVersion is used for Keptn services that do not report their version
+const UnknownVersion = "unknown"
+
+// versionExtensions contains the CloudEvent extension attributes that may contain the version of the sending Keptn service
+var versionExtensions = []string{"serviceversion", "version"}
+
+// KeptnServiceInventory contains the versions of a Keptn service that have sent events for a project
+type KeptnServiceInventory struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Versions godoc
+	Versions map[string]*KeptnServiceVersion `json:"versions" bson:"versions"`
+}
+
+// KeptnServiceVersion godoc
+type KeptnServiceVersion struct {
+	// Version godoc
+	Version string `json:"version" bson:"version"`
+	// Events contains the number of events the Keptn service has sent in this version
+	Events int `json:"events" bson:"events"`
+	// FirstSeen godoc
+	FirstSeen time.Time `json:"firstSeen" bson:"firstSeen"`
+	// LastSeen godoc
+	LastSeen time.Time `json:"lastSeen" bson:"lastSeen"`
+}
+
+// GetKeptnServiceVersion returns the name and the version of the Keptn service that has sent the event.
+// The version is taken from the 'serviceversion' or 'version' extension attribute or, if the source has the format <name>@<version>, from the source.
+// If no version is reported, UnknownVersion is returned
+func (e Event) GetKeptnServiceVersion() (string, string) {
+	name := e.Source
+	version := ""
+	if index := strings.LastIndex(e.Source, "@"); index > 0 && index < len(e.Source)-1 {
+		name = e.Source[:index]
+		version = e.Source[index+1:]
+	}
+	if extensions, ok := e.Extensions.(map[string]interface{}); ok {
+		for _, key := range versionExtensions {
+			if value, ok := extensions[key].(string); ok && value != "" {
+				version = value
+				break
+			}
+		}
+	}
+	if version == "" {
+		version = UnknownVersion
+	}
+	return name, version
+}
+
+// AddKeptnServiceVersion records that the Keptn service has sent an event for the project in the given version
+func (s *Statistics) AddKeptnServiceVersion(projectName, keptnServiceName, version string, seen time.Time) {
+	s.ensureProjectExists(projectName)
+	s.Projects[projectName].addKeptnServiceVersion(keptnServiceName, &KeptnServiceVersion{
+		Version:   version,
+		Events:    1,
+		FirstSeen: seen,
+		LastSeen:  seen,
+	})
+}
+
+func (p *Project) addKeptnServiceVersion(keptnServiceName string, other *KeptnServiceVersion) {
+	if p.KeptnServices == nil {
+		p.KeptnServices = map[string]*KeptnServiceInventory{}
+	}
+	if p.KeptnServices[keptnServiceName] == nil {
+		p.KeptnServices[keptnServiceName] = &KeptnServiceInventory{
+			Name:     keptnServiceName,
+			Versions: map[string]*KeptnServiceVersion{},
+		}
+	}
+	versions := p.KeptnServices[keptnServiceName].Versions
+	version := versions[other.Version]
+	if version == nil {
+		versions[other.Version] = &KeptnServiceVersion{
+			Version:   other.Version,
+			Events:    other.Events,
+			FirstSeen: other.FirstSeen,
+			LastSeen:  other.LastSeen,
+		}
+		return
+	}
+	version.Events = version.Events + other.Events
+	if other.FirstSeen.Before(version.FirstSeen) {
+		version.FirstSeen = other.FirstSeen
+	}
+	if other.LastSeen.After(version.LastSeen) {
+		version.LastSeen = other.LastSeen
+	}
+}
+
+func (p *Project) mergeKeptnServices(other *Project) {
+	for keptnServiceName, keptnService := range other.KeptnServices {
+		for _, version := range keptnService.Versions {
+			p.addKeptnServiceVersion(keptnServiceName, version)
+		}
+	}
+}
+
+// GetKeptnServicesResponse godoc
+type GetKeptnServicesResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// Projects godoc
+	Projects []GetKeptnServicesResponseProject `json:"projects" bson:"projects"`
+}
+
+// GetKeptnServicesResponseProject godoc
+type GetKeptnServicesResponseProject struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// KeptnServices contains the Keptn services that have sent events for the project
+	KeptnServices []GetKeptnServicesResponseKeptnService `json:"keptnServices" bson:"keptnServices"`
+}
+
+// GetKeptnServicesResponseKeptnService godoc
+type GetKeptnServicesResponseKeptnService struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Versions godoc
+	Versions []KeptnServiceVersion `json:"versions" bson:"versions"`
+}
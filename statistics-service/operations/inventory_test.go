@@ -0,0 +1,77 @@
+package operations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvent_GetKeptnServiceVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       Event
+		wantName    string
+		wantVersion string
+	}{
+		{
+			name:        "no version",
+			event:       Event{Source: "helm-service"},
+			wantName:    "helm-service",
+			wantVersion: UnknownVersion,
+		},
+		{
+			name:        "version in source",
+			event:       Event{Source: "helm-service@0.8.0"},
+			wantName:    "helm-service",
+			wantVersion: "0.8.0",
+		},
+		{
+			name:        "version extension",
+			event:       Event{Source: "https://github.com/keptn/keptn/api", Extensions: map[string]interface{}{"serviceversion": "0.8.1"}},
+			wantName:    "https://github.com/keptn/keptn/api",
+			wantVersion: "0.8.1",
+		},
+		{
+			name:        "extension takes precedence over source",
+			event:       Event{Source: "jmeter-service@0.7.0", Extensions: map[string]interface{}{"version": "0.7.1"}},
+			wantName:    "jmeter-service",
+			wantVersion: "0.7.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotVersion := tt.event.GetKeptnServiceVersion()
+			if gotName != tt.wantName || gotVersion != tt.wantVersion {
+				t.Errorf("GetKeptnServiceVersion() = %s, %s, want %s, %s", gotName, gotVersion, tt.wantName, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestMergeStatistics_KeptnServices(t *testing.T) {
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	first := Statistics{}
+	first.AddKeptnServiceVersion("my-project", "helm-service", "0.8.0", start.Add(time.Hour))
+	first.AddKeptnServiceVersion("my-project", "helm-service", "0.8.0", start.Add(2*time.Hour))
+	second := Statistics{}
+	second.AddKeptnServiceVersion("my-project", "helm-service", "0.8.0", start)
+	second.AddKeptnServiceVersion("my-project", "helm-service", "0.8.1", start.Add(3*time.Hour))
+
+	merged := MergeStatistics(Statistics{}, []Statistics{first, second})
+
+	versions := merged.Projects["my-project"].KeptnServices["helm-service"].Versions
+	if len(versions) != 2 {
+		t.Fatalf("MergeStatistics(): want %d versions, got %d", 2, len(versions))
+	}
+	got := versions["0.8.0"]
+	if got.Events != 3 || !got.FirstSeen.Equal(start) || !got.LastSeen.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("MergeStatistics(): unexpected version 0.8.0: %+v", got)
+	}
+	if versions["0.8.1"].Events != 1 {
+		t.Errorf("MergeStatistics(): unexpected version 0.8.1: %+v", versions["0.8.1"])
+	}
+	// the merged statistics must not share versions with the input statistics
+	if first.Projects["my-project"].KeptnServices["helm-service"].Versions["0.8.0"].Events != 2 {
+		t.Error("MergeStatistics(): input statistics have been modified")
+	}
+}
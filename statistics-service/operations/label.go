@@ -0,0 +1,88 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelGroupPrefix is the prefix of groupBy values that group the statistics by a label, e.g. label:team
+const LabelGroupPrefix = "label:"
+
+// LabelStatistics contains the counts of the events that carry a certain label value
+type LabelStatistics struct {
+	// Events godoc
+	Events int `json:"events" bson:"events"`
+	// KeptnServiceExecutions godoc
+	KeptnServiceExecutions int `json:"keptnServiceExecutions" bson:"keptnServiceExecutions"`
+	// ExecutedSequences godoc
+	ExecutedSequences int `json:"executedSequences" bson:"executedSequences"`
+}
+
+func (l *LabelStatistics) add(other LabelStatistics) {
+	l.Events = l.Events + other.Events
+	l.KeptnServiceExecutions = l.KeptnServiceExecutions + other.KeptnServiceExecutions
+	l.ExecutedSequences = l.ExecutedSequences + other.ExecutedSequences
+}
+
+// IncreaseLabelCount adds the counts of an event to the statistics of the given label value
+func (s *Statistics) IncreaseLabelCount(projectName, stageName, serviceName, labelKey, labelValue string, counts LabelStatistics) {
+	s.getService(projectName, stageName, serviceName).addLabelCount(labelKey, labelValue, counts)
+}
+
+func (svc *Service) addLabelCount(labelKey, labelValue string, counts LabelStatistics) {
+	if svc.Labels == nil {
+		svc.Labels = map[string]map[string]*LabelStatistics{}
+	}
+	if svc.Labels[labelKey] == nil {
+		svc.Labels[labelKey] = map[string]*LabelStatistics{}
+	}
+	if svc.Labels[labelKey][labelValue] == nil {
+		svc.Labels[labelKey][labelValue] = &LabelStatistics{}
+	}
+	svc.Labels[labelKey][labelValue].add(counts)
+}
+
+// ParseGroupBy returns the label key of a groupBy value with the format label:<key>
+func ParseGroupBy(groupBy string) (string, error) {
+	if !strings.HasPrefix(groupBy, LabelGroupPrefix) || len(groupBy) == len(LabelGroupPrefix) {
+		return "", fmt.Errorf("unsupported groupBy value '%s'. Use %s<key>, e.g. %steam", groupBy, LabelGroupPrefix, LabelGroupPrefix)
+	}
+	return strings.TrimPrefix(groupBy, LabelGroupPrefix), nil
+}
+
+// GetStatisticsResponseGroup contains the totals of all events that carry the value of the label the statistics are grouped by
+type GetStatisticsResponseGroup struct {
+	// Value godoc
+	Value string `json:"value" bson:"value"`
+	// Events godoc
+	Events int `json:"events" bson:"events"`
+	// KeptnServiceExecutions godoc
+	KeptnServiceExecutions int `json:"keptnServiceExecutions" bson:"keptnServiceExecutions"`
+	// ExecutedSequences godoc
+	ExecutedSequences int `json:"executedSequences" bson:"executedSequences"`
+}
+
+// GroupByLabel returns the totals per value of the given label key over all projects and services
+func (s Statistics) GroupByLabel(labelKey string) []GetStatisticsResponseGroup {
+	totals := map[string]*LabelStatistics{}
+	for _, project := range s.Projects {
+		for _, service := range project.Services {
+			for value, counts := range service.Labels[labelKey] {
+				if totals[value] == nil {
+					totals[value] = &LabelStatistics{}
+				}
+				totals[value].add(*counts)
+			}
+		}
+	}
+	result := []GetStatisticsResponseGroup{}
+	for value, counts := range totals {
+		result = append(result, GetStatisticsResponseGroup{
+			Value:                  value,
+			Events:                 counts.Events,
+			KeptnServiceExecutions: counts.KeptnServiceExecutions,
+			ExecutedSequences:      counts.ExecutedSequences,
+		})
+	}
+	return result
+}
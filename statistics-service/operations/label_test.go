@@ -0,0 +1,56 @@
+package operations
+
+import (
+	"github.com/go-test/deep"
+	"sort"
+	"testing"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		groupBy string
+		want    string
+		wantErr bool
+	}{
+		{groupBy: "label:team", want: "team"},
+		{groupBy: "label:", wantErr: true},
+		{groupBy: "project", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			got, err := ParseGroupBy(tt.groupBy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseGroupBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGroupBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatistics_GroupByLabel(t *testing.T) {
+	first := Statistics{}
+	first.IncreaseLabelCount("my-project", "dev", "carts", "team", "a", LabelStatistics{Events: 1, KeptnServiceExecutions: 1})
+	first.IncreaseLabelCount("my-other-project", "", "orders", "team", "b", LabelStatistics{Events: 1, ExecutedSequences: 1})
+	second := Statistics{}
+	second.IncreaseLabelCount("my-project", "dev", "carts", "team", "a", LabelStatistics{Events: 2})
+	second.IncreaseLabelCount("my-project", "dev", "carts", "owner", "me", LabelStatistics{Events: 2})
+
+	merged := MergeStatistics(Statistics{}, []Statistics{first, second})
+	got := merged.GroupByLabel("team")
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].Value < got[j].Value
+	})
+
+	want := []GetStatisticsResponseGroup{
+		{Value: "a", Events: 3, KeptnServiceExecutions: 1},
+		{Value: "b", Events: 1, ExecutedSequences: 1},
+	}
+	if diff := deep.Equal(got, want); len(diff) > 0 {
+		t.Error("GroupByLabel(): did not get expected groups")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package operations
+
+// RemediationStatistics contains statistics about the problems and the remediation of a service
+type RemediationStatistics struct {
+	// ProblemsOpened godoc
+	ProblemsOpened int `json:"problemsOpened" bson:"problemsOpened"`
+	// ProblemsClosed godoc
+	ProblemsClosed int `json:"problemsClosed" bson:"problemsClosed"`
+	// RemediationsTriggered contains the number of remediation sequences that have been triggered
+	RemediationsTriggered int `json:"remediationsTriggered" bson:"remediationsTriggered"`
+	// Actions contains the executions of remediation actions per action type
+	Actions map[string]*RemediationActionStatistics `json:"actions" bson:"actions"`
+}
+
+// RemediationActionStatistics godoc
+type RemediationActionStatistics struct {
+	// Triggered godoc
+	Triggered int `json:"triggered" bson:"triggered"`
+	// Finished godoc
+	Finished int `json:"finished" bson:"finished"`
+	// Succeeded godoc
+	Succeeded int `json:"succeeded" bson:"succeeded"`
+}
+
+func (r *RemediationStatistics) ensureActionExists(actionType string) *RemediationActionStatistics {
+	if r.Actions == nil {
+		r.Actions = map[string]*RemediationActionStatistics{}
+	}
+	if r.Actions[actionType] == nil {
+		r.Actions[actionType] = &RemediationActionStatistics{}
+	}
+	return r.Actions[actionType]
+}
+
+// Merge adds the values of the given statistics
+func (r *RemediationStatistics) Merge(other *RemediationStatistics) {
+	if other == nil {
+		return
+	}
+	r.ProblemsOpened = r.ProblemsOpened + other.ProblemsOpened
+	r.ProblemsClosed = r.ProblemsClosed + other.ProblemsClosed
+	r.RemediationsTriggered = r.RemediationsTriggered + other.RemediationsTriggered
+	for actionType, action := range other.Actions {
+		target := r.ensureActionExists(actionType)
+		target.Triggered = target.Triggered + action.Triggered
+		target.Finished = target.Finished + action.Finished
+		target.Succeeded = target.Succeeded + action.Succeeded
+	}
+}
+
+// GetStatisticsResponseRemediation godoc
+type GetStatisticsResponseRemediation struct {
+	// ProblemsOpened godoc
+	ProblemsOpened int `json:"problemsOpened" bson:"problemsOpened"`
+	// ProblemsClosed godoc
+	ProblemsClosed int `json:"problemsClosed" bson:"problemsClosed"`
+	// RemediationsTriggered godoc
+	RemediationsTriggered int `json:"remediationsTriggered" bson:"remediationsTriggered"`
+	// Actions godoc
+	Actions []GetStatisticsResponseRemediationAction `json:"actions" bson:"actions"`
+}
+
+// GetStatisticsResponseRemediationAction godoc
+type GetStatisticsResponseRemediationAction struct {
+	// Type godoc
+	Type string `json:"type" bson:"type"`
+	// Triggered godoc
+	Triggered int `json:"triggered" bson:"triggered"`
+	// Finished godoc
+	Finished int `json:"finished" bson:"finished"`
+	// Succeeded godoc
+	Succeeded int `json:"succeeded" bson:"succeeded"`
+	// SuccessRatio contains the ratio of finished actions that have been successful
+	SuccessRatio float64 `json:"successRatio" bson:"successRatio"`
+}
+
+// ToResponse converts the remediation statistics into their API representation
+func (r *RemediationStatistics) ToResponse() *GetStatisticsResponseRemediation {
+	if r == nil {
+		return nil
+	}
+	result := &GetStatisticsResponseRemediation{
+		ProblemsOpened:        r.ProblemsOpened,
+		ProblemsClosed:        r.ProblemsClosed,
+		RemediationsTriggered: r.RemediationsTriggered,
+		Actions:               []GetStatisticsResponseRemediationAction{},
+	}
+	for actionType, action := range r.Actions {
+		newAction := GetStatisticsResponseRemediationAction{
+			Type:      actionType,
+			Triggered: action.Triggered,
+			Finished:  action.Finished,
+			Succeeded: action.Succeeded,
+		}
+		if action.Finished > 0 {
+			newAction.SuccessRatio = float64(action.Succeeded) / float64(action.Finished)
+		}
+		result.Actions = append(result.Actions, newAction)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestRemediationStatistics_ToResponse(t *testing.T) {
+	first := &RemediationStatistics{
+		ProblemsOpened: 2,
+		Actions: map[string]*RemediationActionStatistics{
+			"scaling": {Triggered: 2, Finished: 2, Succeeded: 1},
+		},
+	}
+	second := &RemediationStatistics{
+		ProblemsClosed:        1,
+		RemediationsTriggered: 2,
+		Actions: map[string]*RemediationActionStatistics{
+			"scaling": {Triggered: 2, Finished: 2, Succeeded: 2},
+		},
+	}
+
+	merged := &RemediationStatistics{}
+	merged.Merge(first)
+	merged.Merge(second)
+	merged.Merge(nil)
+
+	got := merged.ToResponse()
+	if got.ProblemsOpened != 2 || got.ProblemsClosed != 1 || got.RemediationsTriggered != 2 {
+		t.Errorf("RemediationStatistics.ToResponse(): unexpected counts: %v", got)
+	}
+	if len(got.Actions) != 1 {
+		t.Fatalf("RemediationStatistics.ToResponse(): want %d action types, got %d", 1, len(got.Actions))
+	}
+	action := got.Actions[0]
+	if action.Type != "scaling" || action.Triggered != 4 || action.Finished != 4 || action.Succeeded != 3 || action.SuccessRatio != 0.75 {
+		t.Errorf("RemediationStatistics.ToResponse(): unexpected action statistics: %v", action)
+	}
+
+	var empty *RemediationStatistics
+	if empty.ToResponse() != nil {
+		t.Error("RemediationStatistics.ToResponse(): expected nil for empty statistics")
+	}
+}
@@ -0,0 +1,300 @@
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortByName godoc
+const SortByName = "name"
+
+// SortByCount godoc
+const SortByCount = "count"
+
+// SortOrder defines the order of all lists of a response. Items with the same count are ordered by name
+type SortOrder struct {
+	// Field is either SortByName or SortByCount
+	Field string
+	// Descending godoc
+	Descending bool
+}
+
+// DefaultSortOrder orders all lists by name in ascending order
+var DefaultSortOrder = SortOrder{Field: SortByName}
+
+// ParseSortOrder parses the 'sort' query parameter, which has the format <field>[:<asc|desc>], e.g. count:desc.
+// Lists are sorted by name in ascending order if the parameter is empty, and by count in descending order if no direction is given
+func ParseSortOrder(value string) (SortOrder, error) {
+	if value == "" {
+		return DefaultSortOrder, nil
+	}
+	parts := strings.SplitN(value, ":", 2)
+	order := SortOrder{Field: parts[0]}
+	switch order.Field {
+	case SortByName:
+	case SortByCount:
+		order.Descending = true
+	default:
+		return SortOrder{}, fmt.Errorf("unsupported sort field '%s'. Supported fields are: %s, %s", parts[0], SortByName, SortByCount)
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			order.Descending = false
+		case "desc":
+			order.Descending = true
+		default:
+			return SortOrder{}, fmt.Errorf("unsupported sort direction '%s'. Supported directions are: asc, desc", parts[1])
+		}
+	}
+	return order, nil
+}
+
+func (o SortOrder) less(nameI, nameJ string, countI, countJ int) bool {
+	if o.Field == SortByCount && countI != countJ {
+		if o.Descending {
+			return countI > countJ
+		}
+		return countI < countJ
+	}
+	if o.Field == SortByName && o.Descending {
+		return nameI > nameJ
+	}
+	return nameI < nameJ
+}
+
+func (o SortOrder) sortEvents(events []GetStatisticsResponseEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return o.less(events[i].Type, events[j].Type, events[i].Count, events[j].Count)
+	})
+}
+
+func (o SortOrder) sortDurations(durations []GetStatisticsResponseDuration) {
+	sort.SliceStable(durations, func(i, j int) bool {
+		return o.less(durations[i].Type, durations[j].Type, durations[i].Count, durations[j].Count)
+	})
+}
+
+func (o SortOrder) sortTriggers(triggers []GetStatisticsResponseTrigger) {
+	sort.SliceStable(triggers, func(i, j int) bool {
+		return o.less(triggers[i].Type+"/"+triggers[i].Source, triggers[j].Type+"/"+triggers[j].Source, triggers[i].Count, triggers[j].Count)
+	})
+}
+
+func sumEvents(events []GetStatisticsResponseEvent) int {
+	count := 0
+	for _, event := range events {
+		count += event.Count
+	}
+	return count
+}
+
+func sumServiceEvents(services []GetStatisticsResponseService) int {
+	count := 0
+	for _, service := range services {
+		count += sumEvents(service.Events)
+	}
+	return count
+}
+
+// Sort orders all lists of the response. Projects, stages and services are counted by their number of events,
+// Keptn services by their number of executions and task results by their number of results
+func (r *GetStatisticsResponse) Sort(order SortOrder) {
+	for index := range r.Projects {
+		project := &r.Projects[index]
+		order.sortTriggers(project.Triggers)
+		order.sortServices(project.Services)
+		for stageIndex := range project.Stages {
+			stage := &project.Stages[stageIndex]
+			order.sortEvents(stage.ExecutedSequences)
+			order.sortServices(stage.Services)
+		}
+		sort.SliceStable(project.Stages, func(i, j int) bool {
+			return order.less(project.Stages[i].Name, project.Stages[j].Name, sumServiceEvents(project.Stages[i].Services), sumServiceEvents(project.Stages[j].Services))
+		})
+	}
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return order.less(r.Projects[i].Name, r.Projects[j].Name, sumServiceEvents(r.Projects[i].Services), sumServiceEvents(r.Projects[j].Services))
+	})
+	sort.SliceStable(r.Groups, func(i, j int) bool {
+		return order.less(r.Groups[i].Value, r.Groups[j].Value, r.Groups[i].Events, r.Groups[j].Events)
+	})
+}
+
+func (o SortOrder) sortServices(services []GetStatisticsResponseService) {
+	for index := range services {
+		service := &services[index]
+		o.sortEvents(service.Events)
+		o.sortEvents(service.ExecutedSequencesPerType)
+		o.sortDurations(service.SequenceDurations)
+		o.sortTriggers(service.Triggers)
+		if service.Remediation != nil {
+			actions := service.Remediation.Actions
+			sort.SliceStable(actions, func(i, j int) bool {
+				return o.less(actions[i].Type, actions[j].Type, actions[i].Triggered, actions[j].Triggered)
+			})
+		}
+		for keptnServiceIndex := range service.KeptnServiceExecutions {
+			keptnService := &service.KeptnServiceExecutions[keptnServiceIndex]
+			o.sortEvents(keptnService.Executions)
+			o.sortDurations(keptnService.Durations)
+			for taskResultsIndex := range keptnService.TaskResults {
+				o.sortEvents(keptnService.TaskResults[taskResultsIndex].Results)
+				o.sortEvents(keptnService.TaskResults[taskResultsIndex].Statuses)
+			}
+			taskResults := keptnService.TaskResults
+			sort.SliceStable(taskResults, func(i, j int) bool {
+				return o.less(taskResults[i].Type, taskResults[j].Type, sumEvents(taskResults[i].Results), sumEvents(taskResults[j].Results))
+			})
+		}
+		keptnServices := service.KeptnServiceExecutions
+		sort.SliceStable(keptnServices, func(i, j int) bool {
+			return o.less(keptnServices[i].Name, keptnServices[j].Name, sumEvents(keptnServices[i].Executions), sumEvents(keptnServices[j].Executions))
+		})
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return o.less(services[i].Name, services[j].Name, sumEvents(services[i].Events), sumEvents(services[j].Events))
+	})
+}
+
+func countEvaluations(evaluations *GetStatisticsResponseEvaluations) int {
+	if evaluations == nil {
+		return 0
+	}
+	return evaluations.Count
+}
+
+// Sort orders the projects, stages and services of the response, which are counted by their number of evaluations
+func (r *GetEvaluationsResponse) Sort(order SortOrder) {
+	sortServices := func(services []GetEvaluationsResponseService) {
+		sort.SliceStable(services, func(i, j int) bool {
+			return order.less(services[i].Name, services[j].Name, countEvaluations(services[i].Evaluations), countEvaluations(services[j].Evaluations))
+		})
+	}
+	for index := range r.Projects {
+		project := &r.Projects[index]
+		sortServices(project.Services)
+		for _, stage := range project.Stages {
+			sortServices(stage.Services)
+		}
+		sort.SliceStable(project.Stages, func(i, j int) bool {
+			return order.less(project.Stages[i].Name, project.Stages[j].Name, countEvaluations(project.Stages[i].Evaluations), countEvaluations(project.Stages[j].Evaluations))
+		})
+	}
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return order.less(r.Projects[i].Name, r.Projects[j].Name, countEvaluations(r.Projects[i].Evaluations), countEvaluations(r.Projects[j].Evaluations))
+	})
+}
+
+func countDeployments(metrics *GetDoraResponseMetrics) int {
+	if metrics == nil {
+		return 0
+	}
+	return metrics.Deployments
+}
+
+// Sort orders the projects, stages and services of the response, which are counted by their number of deployments
+func (r *GetDoraResponse) Sort(order SortOrder) {
+	sortServices := func(services []GetDoraResponseService) {
+		sort.SliceStable(services, func(i, j int) bool {
+			return order.less(services[i].Name, services[j].Name, countDeployments(services[i].Dora), countDeployments(services[j].Dora))
+		})
+	}
+	for index := range r.Projects {
+		project := &r.Projects[index]
+		sortServices(project.Services)
+		for _, stage := range project.Stages {
+			sortServices(stage.Services)
+		}
+		sort.SliceStable(project.Stages, func(i, j int) bool {
+			return order.less(project.Stages[i].Name, project.Stages[j].Name, countDeployments(project.Stages[i].Dora), countDeployments(project.Stages[j].Dora))
+		})
+	}
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return order.less(r.Projects[i].Name, r.Projects[j].Name, countDeployments(r.Projects[i].Dora), countDeployments(r.Projects[j].Dora))
+	})
+}
+
+func sumVersionEvents(versions []KeptnServiceVersion) int {
+	count := 0
+	for _, version := range versions {
+		count += version.Events
+	}
+	return count
+}
+
+// Sort orders the projects, Keptn services and versions of the response, which are counted by their number of events
+func (r *GetKeptnServicesResponse) Sort(order SortOrder) {
+	sumKeptnServiceEvents := func(keptnServices []GetKeptnServicesResponseKeptnService) int {
+		count := 0
+		for _, keptnService := range keptnServices {
+			count += sumVersionEvents(keptnService.Versions)
+		}
+		return count
+	}
+	for index := range r.Projects {
+		keptnServices := r.Projects[index].KeptnServices
+		for _, keptnService := range keptnServices {
+			versions := keptnService.Versions
+			sort.SliceStable(versions, func(i, j int) bool {
+				return order.less(versions[i].Version, versions[j].Version, versions[i].Events, versions[j].Events)
+			})
+		}
+		sort.SliceStable(keptnServices, func(i, j int) bool {
+			return order.less(keptnServices[i].Name, keptnServices[j].Name, sumVersionEvents(keptnServices[i].Versions), sumVersionEvents(keptnServices[j].Versions))
+		})
+	}
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return order.less(r.Projects[i].Name, r.Projects[j].Name, sumKeptnServiceEvents(r.Projects[i].KeptnServices), sumKeptnServiceEvents(r.Projects[j].KeptnServices))
+	})
+}
+
+// Sort orders the groups of the response by their number of automation units, and their Keptn service executions by count
+func (r *GetSummaryResponse) Sort(order SortOrder) {
+	for _, group := range r.Groups {
+		executions := group.KeptnServiceExecutions
+		sort.SliceStable(executions, func(i, j int) bool {
+			return order.less(executions[i].Name+"/"+executions[i].Type, executions[j].Name+"/"+executions[j].Type, executions[i].Count, executions[j].Count)
+		})
+	}
+	sort.SliceStable(r.Groups, func(i, j int) bool {
+		return order.less(r.Groups[i].Project+"/"+r.Groups[i].Service, r.Groups[j].Project+"/"+r.Groups[j].Service, r.Groups[i].AutomationUnits, r.Groups[j].AutomationUnits)
+	})
+}
+
+func absoluteDelta(delta GetCompareResponseDelta) int {
+	if delta.Delta < 0 {
+		return -delta.Delta
+	}
+	return delta.Delta
+}
+
+func (o SortOrder) sortCompareEventTypes(eventTypes []GetCompareResponseEventType) {
+	sort.SliceStable(eventTypes, func(i, j int) bool {
+		return o.less(eventTypes[i].Type, eventTypes[j].Type, absoluteDelta(eventTypes[i].Count), absoluteDelta(eventTypes[j].Count))
+	})
+}
+
+// Sort orders all lists of the response, which are counted by the absolute delta of their events or executions
+func (r *GetCompareResponse) Sort(order SortOrder) {
+	for index := range r.Projects {
+		services := r.Projects[index].Services
+		for _, service := range services {
+			order.sortCompareEventTypes(service.EventTypes)
+			for _, keptnService := range service.KeptnServices {
+				order.sortCompareEventTypes(keptnService.EventTypes)
+			}
+			keptnServices := service.KeptnServices
+			sort.SliceStable(keptnServices, func(i, j int) bool {
+				return order.less(keptnServices[i].Name, keptnServices[j].Name, absoluteDelta(keptnServices[i].Executions), absoluteDelta(keptnServices[j].Executions))
+			})
+		}
+		sort.SliceStable(services, func(i, j int) bool {
+			return order.less(services[i].Name, services[j].Name, absoluteDelta(services[i].Events), absoluteDelta(services[j].Events))
+		})
+	}
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return order.less(r.Projects[i].Name, r.Projects[j].Name, absoluteDelta(r.Projects[i].Events), absoluteDelta(r.Projects[j].Events))
+	})
+}
@@ -0,0 +1,81 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SortOrder
+		wantErr bool
+	}{
+		{value: "", want: SortOrder{Field: SortByName}},
+		{value: "name:desc", want: SortOrder{Field: SortByName, Descending: true}},
+		{value: "count", want: SortOrder{Field: SortByCount, Descending: true}},
+		{value: "count:asc", want: SortOrder{Field: SortByCount}},
+		{value: "size", wantErr: true},
+		{value: "name:up", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSortOrder(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSortOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSortOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetStatisticsResponse_Sort(t *testing.T) {
+	newResponse := func() GetStatisticsResponse {
+		return GetStatisticsResponse{
+			Projects: []GetStatisticsResponseProject{
+				{
+					Name: "sockshop",
+					Services: []GetStatisticsResponseService{
+						{
+							Name:   "carts",
+							Events: []GetStatisticsResponseEvent{{Type: "b", Count: 1}, {Type: "a", Count: 1}, {Type: "c", Count: 5}},
+						},
+						{
+							Name:   "cart-db",
+							Events: []GetStatisticsResponseEvent{{Type: "a", Count: 10}},
+						},
+					},
+				},
+				{
+					Name: "podtato-head",
+					Services: []GetStatisticsResponseService{
+						{
+							Name:   "helloservice",
+							Events: []GetStatisticsResponseEvent{{Type: "a", Count: 2}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	byName := newResponse()
+	byName.Sort(DefaultSortOrder)
+	if byName.Projects[0].Name != "podtato-head" || byName.Projects[1].Services[0].Name != "cart-db" {
+		t.Errorf("Sort(): unexpected order by name: %+v", byName.Projects)
+	}
+	if events := byName.Projects[1].Services[1].Events; events[0].Type != "a" || events[1].Type != "b" || events[2].Type != "c" {
+		t.Errorf("Sort(): unexpected order of events by name: %+v", events)
+	}
+
+	byCount := newResponse()
+	byCount.Sort(SortOrder{Field: SortByCount, Descending: true})
+	if byCount.Projects[0].Name != "sockshop" || byCount.Projects[0].Services[0].Name != "cart-db" {
+		t.Errorf("Sort(): unexpected order by count: %+v", byCount.Projects)
+	}
+	// items with the same count are ordered by name
+	if events := byCount.Projects[0].Services[1].Events; events[0].Type != "c" || events[1].Type != "a" || events[2].Type != "b" {
+		t.Errorf("Sort(): unexpected order of events by count: %+v", events)
+	}
+}
@@ -6,10 +6,32 @@ import (
 
 // GetStatisticsParams godoc
 type GetStatisticsParams struct {
-	// From godoc
-	From time.Time `form:"from" json:"from" time_format:"unix"`
-	// To godoc
-	To time.Time `form:"to" json:"to" time_format:"unix"`
+	// FromParam contains the start of the time frame as an RFC3339 timestamp, a Unix timestamp in seconds or milliseconds, or a relative expression, e.g. now-7d
+	FromParam string `form:"from" json:"-"`
+	// ToParam contains the end of the time frame in any of the formats accepted by FromParam
+	ToParam string `form:"to" json:"-"`
+	// Period selects a calendar period, e.g. lastMonth, instead of 'from' and 'to'
+	Period string `form:"period" json:"period"`
+	// Timezone contains the IANA time zone in which relative expressions and periods are evaluated, e.g. Europe/Vienna
+	Timezone string `form:"timezone" json:"timezone"`
+	// From is set by ResolveTimeFrame
+	From time.Time `form:"-" json:"from"`
+	// To is set by ResolveTimeFrame
+	To time.Time `form:"-" json:"to"`
+	// Stage godoc
+	Stage string `form:"stage" json:"stage"`
+	// GroupBy groups the statistics by a label, e.g. label:team
+	GroupBy string `form:"groupBy" json:"groupBy"`
+	// Project contains a comma-separated list of project names, which may contain '*' as a wildcard
+	Project string `form:"project" json:"project"`
+	// Service contains a comma-separated list of service names, which may contain '*' as a wildcard
+	Service string `form:"service" json:"service"`
+	// KeptnService contains a comma-separated list of Keptn service names, which may contain '*' as a wildcard
+	KeptnService string `form:"keptnService" json:"keptnService"`
+	// EventType contains a comma-separated list of event types or task names, which may contain '*' as a wildcard
+	EventType string `form:"eventType" json:"eventType"`
+	// Sort defines the order of all lists of the response, e.g. count:desc
+	Sort string `form:"sort" json:"sort"`
 }
 
 // GetStatisticsResponse godoc
@@ -18,14 +40,46 @@ type GetStatisticsResponse struct {
 	From time.Time `json:"from" bson:"from"`
 	// To godoc
 	To time.Time `json:"to" bson:"to"`
+	// UniqueSequences godoc
+	UniqueSequences int `json:"uniqueSequences" bson:"uniqueSequences"`
 	// Projects godoc
 	Projects []GetStatisticsResponseProject `json:"projects" bson:"projects"`
+	// Overflows godoc
+	Overflows map[string]int `json:"overflows,omitempty" bson:"overflows,omitempty"`
+	// FilteredEvents godoc
+	FilteredEvents map[string]int `json:"filteredEvents,omitempty" bson:"filteredEvents,omitempty"`
+	// GroupBy godoc
+	GroupBy string `json:"groupBy,omitempty" bson:"groupBy,omitempty"`
+	// Groups contains the totals per label value if the statistics are grouped by a label
+	Groups []GetStatisticsResponseGroup `json:"groups,omitempty" bson:"groups,omitempty"`
 }
 
 // GetStatisticsResponseProject godoc
 type GetStatisticsResponseProject struct {
 	// Name godoc
 	Name string `json:"name" bson:"name"`
+	// UniqueSequences godoc
+	UniqueSequences int `json:"uniqueSequences" bson:"uniqueSequences"`
+	// Approvals contains the approvals of all services of the project
+	Approvals *GetStatisticsResponseApprovals `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	// Triggers contains the number of sequences of all services of the project per trigger type and source
+	Triggers []GetStatisticsResponseTrigger `json:"triggers,omitempty" bson:"triggers,omitempty"`
+	// Services godoc
+	Services []GetStatisticsResponseService `json:"services" bson:"services"`
+	// Stages godoc
+	Stages []GetStatisticsResponseStage `json:"stages,omitempty" bson:"stages,omitempty"`
+}
+
+// GetStatisticsResponseStage godoc
+type GetStatisticsResponseStage struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// UniqueSequences godoc
+	UniqueSequences int `json:"uniqueSequences" bson:"uniqueSequences"`
+	// Approvals contains the approvals of all services of the stage
+	Approvals *GetStatisticsResponseApprovals `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	// ExecutedSequences contains the number of executed sequences of all services of the stage per sequence
+	ExecutedSequences []GetStatisticsResponseEvent `json:"executedSequences,omitempty" bson:"executedSequences,omitempty"`
 	// Services godoc
 	Services []GetStatisticsResponseService `json:"services" bson:"services"`
 }
@@ -34,12 +88,24 @@ type GetStatisticsResponseProject struct {
 type GetStatisticsResponseService struct {
 	// Name godoc
 	Name string `json:"name" bson:"name"`
+	// UniqueSequences godoc
+	UniqueSequences int `json:"uniqueSequences" bson:"uniqueSequences"`
 	// Events godoc
 	Events []GetStatisticsResponseEvent `json:"events" bson:"events"`
 	// KeptnServiceExecutions godoc
 	KeptnServiceExecutions []GetStatisticsResponseKeptnService `json:"keptnServiceExecutions" bson:"keptnServiceExecutions"`
 	// ExecutedSequencesPerType godoc
 	ExecutedSequencesPerType []GetStatisticsResponseEvent `json:"executedSequencesPerType,omitempty" bson:"executedSequencesPerType"`
+	// SequenceDurations godoc
+	SequenceDurations []GetStatisticsResponseDuration `json:"sequenceDurations,omitempty" bson:"sequenceDurations,omitempty"`
+	// Evaluations godoc
+	Evaluations *GetStatisticsResponseEvaluations `json:"evaluations,omitempty" bson:"evaluations,omitempty"`
+	// Remediation godoc
+	Remediation *GetStatisticsResponseRemediation `json:"remediation,omitempty" bson:"remediation,omitempty"`
+	// Approvals godoc
+	Approvals *GetStatisticsResponseApprovals `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	// Triggers godoc
+	Triggers []GetStatisticsResponseTrigger `json:"triggers,omitempty" bson:"triggers,omitempty"`
 }
 
 // GetStatisticsResponseEvent godoc+
@@ -56,8 +122,25 @@ type GetStatisticsResponseKeptnService struct {
 	Name string `json:"name" bson:"name"`
 	// Executions godoc
 	Executions []GetStatisticsResponseEvent `json:"executions" bson:"executions"`
+	// Durations godoc
+	Durations []GetStatisticsResponseDuration `json:"durations,omitempty" bson:"durations,omitempty"`
+	// TaskResults godoc
+	TaskResults []GetStatisticsResponseTaskResults `json:"taskResults,omitempty" bson:"taskResults,omitempty"`
+}
+
+// GetStatisticsResponseTaskResults godoc
+type GetStatisticsResponseTaskResults struct {
+	// Type godoc
+	Type string `json:"type" bson:"type"`
+	// Results godoc
+	Results []GetStatisticsResponseEvent `json:"results" bson:"results"`
+	// Statuses godoc
+	Statuses []GetStatisticsResponseEvent `json:"statuses" bson:"statuses"`
 }
 
+// OverflowKey is counted instead of the names of projects, services, Keptn services and event types that exceed the cardinality limits
+const OverflowKey = "__other__"
+
 // Statistics godoc
 type Statistics struct {
 	// From godoc
@@ -66,10 +149,30 @@ type Statistics struct {
 	To time.Time `json:"to" bson:"to"`
 	// Projects godoc
 	Projects map[string]*Project `json:"projects" bson:"projects"`
+	// Overflows contains the number of events per dimension (project, service, keptnService or eventType) that have been counted as OverflowKey because the cardinality limit was reached, and the number of services whose uniqueSequences have been dropped because the bucket exceeded its maximum size
+	Overflows map[string]int `json:"overflows,omitempty" bson:"overflows,omitempty"`
+	// FilteredEvents contains the number of events per dimension (project, service, source or eventType) that have been dropped by the ingestion filter
+	FilteredEvents map[string]int `json:"filteredEvents,omitempty" bson:"filteredEvents,omitempty"`
+	// StagesOnly is set if the events of a stage are only counted in the statistics of the stage. Otherwise, the services of a project contain the totals of all stages
+	StagesOnly bool `json:"stagesOnly,omitempty" bson:"stagesOnly,omitempty"`
+	// TaskNames is set if executions, sequences, durations and task results are counted per task name. Statistics of previous versions count them per event type and are normalized when they are merged
+	TaskNames bool `json:"taskNames,omitempty" bson:"taskNames,omitempty"`
 }
 
 // Project godoc
 type Project struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Services contains the statistics of the project's services. If the statistics are StagesOnly, it only contains events without a stage
+	Services map[string]*Service `json:"services" bson:"services"`
+	// Stages contains the statistics of the project's services, broken down by stage. Buckets created before stages have been tracked do not contain this property
+	Stages map[string]*Stage `json:"stages,omitempty" bson:"stages,omitempty"`
+	// KeptnServices contains the versions of the Keptn services that have sent events for the project
+	KeptnServices map[string]*KeptnServiceInventory `json:"keptnServices,omitempty" bson:"keptnServices,omitempty"`
+}
+
+// Stage godoc
+type Stage struct {
 	// Name godoc
 	Name string `json:"name" bson:"name"`
 	// Services godoc
@@ -88,6 +191,22 @@ type Service struct {
 	Events map[string]int `json:"events" bson:"events"`
 	// KeptnServiceExecutions godoc
 	KeptnServiceExecutions map[string]*KeptnService `json:"keptnServiceExecutions" bson:"keptnServiceExecutions"`
+	// UniqueSequences contains a sketch of the distinct Keptn contexts that have been observed for the service
+	UniqueSequences *HyperLogLog `json:"uniqueSequences,omitempty" bson:"uniqueSequences,omitempty"`
+	// SequenceDurations contains the durations of completed sequences per sequence type
+	SequenceDurations map[string]*DurationStatistics `json:"sequenceDurations,omitempty" bson:"sequenceDurations,omitempty"`
+	// Evaluations godoc
+	Evaluations *EvaluationStatistics `json:"evaluations,omitempty" bson:"evaluations,omitempty"`
+	// Dora contains the signals that are needed to compute the DORA metrics of the service
+	Dora *DoraStatistics `json:"dora,omitempty" bson:"dora,omitempty"`
+	// Remediation godoc
+	Remediation *RemediationStatistics `json:"remediation,omitempty" bson:"remediation,omitempty"`
+	// Approvals godoc
+	Approvals *ApprovalStatistics `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	// Triggers contains the number of sequences per trigger type and source of the triggering event
+	Triggers map[string]map[string]int `json:"triggers,omitempty" bson:"triggers,omitempty"`
+	// Labels contains the counts per key and value of the labels that have been promoted to dimensions
+	Labels map[string]map[string]*LabelStatistics `json:"labels,omitempty" bson:"labels,omitempty"`
 }
 
 // KeptnService godoc
@@ -96,6 +215,44 @@ type KeptnService struct {
 	Name string `json:"name" bson:"name"`
 	// Executions godoc
 	Executions map[string]int `json:"executions" bson:"executions"`
+	// Durations contains the durations of the tasks executed by the Keptn service per task type
+	Durations map[string]*DurationStatistics `json:"durations,omitempty" bson:"durations,omitempty"`
+	// TaskResults contains the results and statuses of the finished events sent by the Keptn service per task type
+	TaskResults map[string]*TaskResults `json:"taskResults,omitempty" bson:"taskResults,omitempty"`
+}
+
+// TaskResults godoc
+type TaskResults struct {
+	// Results godoc
+	Results map[string]int `json:"results" bson:"results"`
+	// Statuses godoc
+	Statuses map[string]int `json:"statuses" bson:"statuses"`
+}
+
+func (k *KeptnService) ensureTaskResultsExist(taskType string) *TaskResults {
+	if k.TaskResults == nil {
+		k.TaskResults = map[string]*TaskResults{}
+	}
+	if k.TaskResults[taskType] == nil {
+		k.TaskResults[taskType] = &TaskResults{}
+	}
+	if k.TaskResults[taskType].Results == nil {
+		k.TaskResults[taskType].Results = map[string]int{}
+	}
+	if k.TaskResults[taskType].Statuses == nil {
+		k.TaskResults[taskType].Statuses = map[string]int{}
+	}
+	return k.TaskResults[taskType]
+}
+
+func newService(serviceName string) *Service {
+	return &Service{
+		Name:                     serviceName,
+		ExecutedSequences:        0,
+		ExecutedSequencesPerType: map[string]int{},
+		Events:                   map[string]int{},
+		KeptnServiceExecutions:   map[string]*KeptnService{},
+	}
 }
 
 func (s *Statistics) ensureProjectAndServiceExist(projectName string, serviceName string) {
@@ -104,27 +261,28 @@ func (s *Statistics) ensureProjectAndServiceExist(projectName string, serviceNam
 		s.Projects[projectName].Services = map[string]*Service{}
 	}
 	if s.Projects[projectName].Services[serviceName] == nil {
-		s.Projects[projectName].Services[serviceName] = &Service{
-			Name:                     serviceName,
-			ExecutedSequences:        0,
-			ExecutedSequencesPerType: map[string]int{},
-			Events:                   map[string]int{},
-			KeptnServiceExecutions:   map[string]*KeptnService{},
-		}
+		s.Projects[projectName].Services[serviceName] = newService(serviceName)
 	}
 }
 
-func (s *Statistics) ensureKeptnServiceExists(projectName, serviceName, keptnServiceName string) {
-	s.ensureProjectAndServiceExist(projectName, serviceName)
-	if s.Projects[projectName].Services[serviceName].KeptnServiceExecutions == nil {
-		s.Projects[projectName].Services[serviceName].KeptnServiceExecutions = map[string]*KeptnService{}
+func (s *Statistics) ensureStageAndServiceExist(projectName, stageName, serviceName string) {
+	s.ensureProjectExists(projectName)
+	project := s.Projects[projectName]
+	if project.Stages == nil {
+		project.Stages = map[string]*Stage{}
 	}
-	if s.Projects[projectName].Services[serviceName].KeptnServiceExecutions[keptnServiceName] == nil {
-		s.Projects[projectName].Services[serviceName].KeptnServiceExecutions[keptnServiceName] = &KeptnService{
-			Name:       keptnServiceName,
-			Executions: map[string]int{},
+	if project.Stages[stageName] == nil {
+		project.Stages[stageName] = &Stage{
+			Name:     stageName,
+			Services: map[string]*Service{},
 		}
 	}
+	if project.Stages[stageName].Services == nil {
+		project.Stages[stageName].Services = map[string]*Service{}
+	}
+	if project.Stages[stageName].Services[serviceName] == nil {
+		project.Stages[stageName].Services[serviceName] = newService(serviceName)
+	}
 }
 
 func (s *Statistics) ensureProjectExists(projectName string) {
@@ -139,54 +297,520 @@ func (s *Statistics) ensureProjectExists(projectName string) {
 	}
 }
 
+// getService returns the statistics of a service that an event is counted in. Events with a stage are only counted in the statistics of the stage;
+// the totals of the project are computed when the statistics are merged
+func (s *Statistics) getService(projectName, stageName, serviceName string) *Service {
+	s.TaskNames = true
+	if stageName == "" {
+		s.ensureProjectAndServiceExist(projectName, serviceName)
+		return s.Projects[projectName].Services[serviceName]
+	}
+	s.StagesOnly = true
+	s.ensureStageAndServiceExist(projectName, stageName, serviceName)
+	return s.Projects[projectName].Stages[stageName].Services[serviceName]
+}
+
+// CountServices returns the number of distinct services of all projects and stages
+func (s Statistics) CountServices() int {
+	count := 0
+	for _, project := range s.Projects {
+		serviceNames := map[string]bool{}
+		for serviceName := range project.Services {
+			serviceNames[serviceName] = true
+		}
+		for _, stage := range project.Stages {
+			for serviceName := range stage.Services {
+				serviceNames[serviceName] = true
+			}
+		}
+		count += len(serviceNames)
+	}
+	return count
+}
+
+// RemoveUniqueSequences removes the sketches of the unique sequences of all services and returns the number of removed sketches
+func (s *Statistics) RemoveUniqueSequences() int {
+	removed := 0
+	removeFromServices := func(services map[string]*Service) {
+		for _, service := range services {
+			if service.UniqueSequences != nil {
+				service.UniqueSequences = nil
+				removed++
+			}
+		}
+	}
+	for _, project := range s.Projects {
+		removeFromServices(project.Services)
+		for _, stage := range project.Stages {
+			removeFromServices(stage.Services)
+		}
+	}
+	return removed
+}
+
+func (svc *Service) ensureKeptnServiceExists(keptnServiceName string) *KeptnService {
+	if svc.KeptnServiceExecutions == nil {
+		svc.KeptnServiceExecutions = map[string]*KeptnService{}
+	}
+	if svc.KeptnServiceExecutions[keptnServiceName] == nil {
+		svc.KeptnServiceExecutions[keptnServiceName] = &KeptnService{
+			Name:       keptnServiceName,
+			Executions: map[string]int{},
+		}
+	}
+	if svc.KeptnServiceExecutions[keptnServiceName].Executions == nil {
+		svc.KeptnServiceExecutions[keptnServiceName].Executions = map[string]int{}
+	}
+	return svc.KeptnServiceExecutions[keptnServiceName]
+}
+
+func (svc *Service) ensureDoraExists() *DoraStatistics {
+	if svc.Dora == nil {
+		svc.Dora = &DoraStatistics{}
+	}
+	return svc.Dora
+}
+
+func (svc *Service) ensureRemediationExists() *RemediationStatistics {
+	if svc.Remediation == nil {
+		svc.Remediation = &RemediationStatistics{}
+	}
+	return svc.Remediation
+}
+
+func (svc *Service) ensureApprovalsExist() *ApprovalStatistics {
+	if svc.Approvals == nil {
+		svc.Approvals = &ApprovalStatistics{}
+	}
+	return svc.Approvals
+}
+
+func (svc *Service) ensureMapsExist() {
+	if svc.Events == nil {
+		svc.Events = map[string]int{}
+	}
+	if svc.ExecutedSequencesPerType == nil {
+		svc.ExecutedSequencesPerType = map[string]int{}
+	}
+	if svc.KeptnServiceExecutions == nil {
+		svc.KeptnServiceExecutions = map[string]*KeptnService{}
+	}
+}
+
 // IncreaseEventTypeCount godoc
-func (s *Statistics) IncreaseEventTypeCount(projectName, serviceName, eventType string, increment int) {
-	s.ensureProjectAndServiceExist(projectName, serviceName)
-	service := s.Projects[projectName].Services[serviceName]
+func (s *Statistics) IncreaseEventTypeCount(projectName, stageName, serviceName, eventType string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	service.ensureMapsExist()
 	service.Events[eventType] = service.Events[eventType] + increment
 }
 
 // IncreaseExecutedSequencesCount godoc
-func (s *Statistics) IncreaseExecutedSequencesCount(projectName, serviceName string, increment int) {
-	s.ensureProjectAndServiceExist(projectName, serviceName)
-	service := s.Projects[projectName].Services[serviceName]
+func (s *Statistics) IncreaseExecutedSequencesCount(projectName, stageName, serviceName string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
 	service.ExecutedSequences = service.ExecutedSequences + increment
 }
 
 // IncreaseKeptnServiceExecutionCount godoc
-func (s *Statistics) IncreaseKeptnServiceExecutionCount(projectName, serviceName, keptnServiceName, eventType string, increment int) {
-	s.ensureProjectAndServiceExist(projectName, serviceName)
-	s.ensureKeptnServiceExists(projectName, serviceName, keptnServiceName)
-	keptnService := s.Projects[projectName].Services[serviceName].KeptnServiceExecutions[keptnServiceName]
+func (s *Statistics) IncreaseKeptnServiceExecutionCount(projectName, stageName, serviceName, keptnServiceName, eventType string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	keptnService := service.ensureKeptnServiceExists(keptnServiceName)
 	keptnService.Executions[eventType] = keptnService.Executions[eventType] + increment
 }
 
 // IncreaseExecutedSequenceCountForType godoc
-func (s *Statistics) IncreaseExecutedSequenceCountForType(projectName string, serviceName string, eventType string, increment int) {
-	s.ensureProjectAndServiceExist(projectName, serviceName)
-	service := s.Projects[projectName].Services[serviceName]
+func (s *Statistics) IncreaseExecutedSequenceCountForType(projectName, stageName, serviceName, eventType string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	service.ensureMapsExist()
 	service.ExecutedSequencesPerType[eventType] = service.ExecutedSequencesPerType[eventType] + increment
 }
 
-// MergeStatistics godoc
+// AddUniqueSequence godoc
+func (s *Statistics) AddUniqueSequence(projectName, stageName, serviceName, keptnContext string) {
+	service := s.getService(projectName, stageName, serviceName)
+	if service.UniqueSequences == nil {
+		service.UniqueSequences = NewHyperLogLog()
+	}
+	service.UniqueSequences.Add(keptnContext)
+}
+
+// IncreaseTaskResultCount godoc
+func (s *Statistics) IncreaseTaskResultCount(projectName, stageName, serviceName, keptnServiceName, taskType, result, status string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	taskResults := service.ensureKeptnServiceExists(keptnServiceName).ensureTaskResultsExist(taskType)
+	if result != "" {
+		taskResults.Results[result] = taskResults.Results[result] + increment
+	}
+	if status != "" {
+		taskResults.Statuses[status] = taskResults.Statuses[status] + increment
+	}
+}
+
+// AddEvaluation godoc
+func (s *Statistics) AddEvaluation(projectName, stageName, serviceName, result string, score float64, hasScore bool) {
+	service := s.getService(projectName, stageName, serviceName)
+	if service.Evaluations == nil {
+		service.Evaluations = NewEvaluationStatistics()
+	}
+	service.Evaluations.Add(result, score, hasScore)
+}
+
+// AddTaskDuration godoc
+func (s *Statistics) AddTaskDuration(projectName, stageName, serviceName, keptnServiceName, taskType string, duration time.Duration) {
+	service := s.getService(projectName, stageName, serviceName)
+	keptnService := service.ensureKeptnServiceExists(keptnServiceName)
+	if keptnService.Durations == nil {
+		keptnService.Durations = map[string]*DurationStatistics{}
+	}
+	if keptnService.Durations[taskType] == nil {
+		keptnService.Durations[taskType] = NewDurationStatistics()
+	}
+	keptnService.Durations[taskType].Add(duration)
+}
+
+// AddSequenceDuration godoc
+func (s *Statistics) AddSequenceDuration(projectName, stageName, serviceName, sequenceType string, duration time.Duration) {
+	service := s.getService(projectName, stageName, serviceName)
+	if service.SequenceDurations == nil {
+		service.SequenceDurations = map[string]*DurationStatistics{}
+	}
+	if service.SequenceDurations[sequenceType] == nil {
+		service.SequenceDurations[sequenceType] = NewDurationStatistics()
+	}
+	service.SequenceDurations[sequenceType].Add(duration)
+}
+
+// AddDeployment godoc
+func (s *Statistics) AddDeployment(projectName, stageName, serviceName string, failed bool) {
+	service := s.getService(projectName, stageName, serviceName)
+	dora := service.ensureDoraExists()
+	dora.Deployments = dora.Deployments + 1
+	if failed {
+		dora.FailedDeployments = dora.FailedDeployments + 1
+	}
+}
+
+// IncreaseFailedDeploymentCount increases the number of failed deployments for deployments that have been marked as failed after they have been finished, e.g. by an evaluation
+func (s *Statistics) IncreaseFailedDeploymentCount(projectName, stageName, serviceName string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	dora := service.ensureDoraExists()
+	dora.FailedDeployments = dora.FailedDeployments + increment
+}
+
+// AddLeadTime godoc
+func (s *Statistics) AddLeadTime(projectName, stageName, serviceName string, duration time.Duration) {
+	service := s.getService(projectName, stageName, serviceName)
+	dora := service.ensureDoraExists()
+	if dora.LeadTimes == nil {
+		dora.LeadTimes = NewDurationStatistics()
+	}
+	dora.LeadTimes.Add(duration)
+}
+
+// IncreaseIncidentCount godoc
+func (s *Statistics) IncreaseIncidentCount(projectName, stageName, serviceName string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	dora := service.ensureDoraExists()
+	dora.Incidents = dora.Incidents + increment
+}
+
+// AddTimeToRestore godoc
+func (s *Statistics) AddTimeToRestore(projectName, stageName, serviceName string, duration time.Duration) {
+	service := s.getService(projectName, stageName, serviceName)
+	dora := service.ensureDoraExists()
+	if dora.TimesToRestore == nil {
+		dora.TimesToRestore = NewDurationStatistics()
+	}
+	dora.TimesToRestore.Add(duration)
+}
+
+// IncreaseProblemCount increases the number of opened or closed problems
+func (s *Statistics) IncreaseProblemCount(projectName, stageName, serviceName string, closed bool, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	remediation := service.ensureRemediationExists()
+	if closed {
+		remediation.ProblemsClosed = remediation.ProblemsClosed + increment
+	} else {
+		remediation.ProblemsOpened = remediation.ProblemsOpened + increment
+	}
+}
+
+// IncreaseRemediationCount godoc
+func (s *Statistics) IncreaseRemediationCount(projectName, stageName, serviceName string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	remediation := service.ensureRemediationExists()
+	remediation.RemediationsTriggered = remediation.RemediationsTriggered + increment
+}
+
+// IncreaseRemediationActionTriggeredCount godoc
+func (s *Statistics) IncreaseRemediationActionTriggeredCount(projectName, stageName, serviceName, actionType string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	action := service.ensureRemediationExists().ensureActionExists(actionType)
+	action.Triggered = action.Triggered + increment
+}
+
+// IncreaseRemediationActionFinishedCount godoc
+func (s *Statistics) IncreaseRemediationActionFinishedCount(projectName, stageName, serviceName, actionType string, succeeded bool, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	action := service.ensureRemediationExists().ensureActionExists(actionType)
+	action.Finished = action.Finished + increment
+	if succeeded {
+		action.Succeeded = action.Succeeded + increment
+	}
+}
+
+// IncreaseApprovalRequestCount godoc
+func (s *Statistics) IncreaseApprovalRequestCount(projectName, stageName, serviceName string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	approvals := service.ensureApprovalsExist()
+	approvals.Requested = approvals.Requested + increment
+}
+
+// AddApproval adds a finished approval. If the time to approve is not known, it is set to a negative value
+func (s *Statistics) AddApproval(projectName, stageName, serviceName string, approved, automatic bool, timeToApprove time.Duration) {
+	service := s.getService(projectName, stageName, serviceName)
+	approvals := service.ensureApprovalsExist()
+	if approved {
+		approvals.Approved = approvals.Approved + 1
+	} else {
+		approvals.Rejected = approvals.Rejected + 1
+	}
+	if automatic {
+		approvals.Automatic = approvals.Automatic + 1
+	} else {
+		approvals.Manual = approvals.Manual + 1
+	}
+	if timeToApprove >= 0 {
+		if approvals.TimesToApprove == nil {
+			approvals.TimesToApprove = NewDurationStatistics()
+		}
+		approvals.TimesToApprove.Add(timeToApprove)
+	}
+}
+
+// IncreaseOverflowCount godoc
+func (s *Statistics) IncreaseOverflowCount(dimension string, increment int) {
+	if s.Overflows == nil {
+		s.Overflows = map[string]int{}
+	}
+	s.Overflows[dimension] = s.Overflows[dimension] + increment
+}
+
+// IncreaseFilteredEventCount godoc
+func (s *Statistics) IncreaseFilteredEventCount(dimension string, increment int) {
+	if s.FilteredEvents == nil {
+		s.FilteredEvents = map[string]int{}
+	}
+	s.FilteredEvents[dimension] = s.FilteredEvents[dimension] + increment
+}
+
+// IncreaseTriggerCount godoc
+func (s *Statistics) IncreaseTriggerCount(projectName, stageName, serviceName, triggerType, source string, increment int) {
+	service := s.getService(projectName, stageName, serviceName)
+	if service.Triggers == nil {
+		service.Triggers = map[string]map[string]int{}
+	}
+	if service.Triggers[triggerType] == nil {
+		service.Triggers[triggerType] = map[string]int{}
+	}
+	service.Triggers[triggerType][source] = service.Triggers[triggerType][source] + increment
+}
+
+// FilterStage returns a copy of the statistics that only contains the services of the given stage.
+// Projects that do not have any statistics for the stage are omitted. The overflows, the filtered events and the Keptn services are not tracked per stage and are kept
+func (s Statistics) FilterStage(stageName string) Statistics {
+	result := Statistics{
+		From:           s.From,
+		To:             s.To,
+		Projects:       map[string]*Project{},
+		Overflows:      s.Overflows,
+		FilteredEvents: s.FilteredEvents,
+	}
+	for projectName, project := range s.Projects {
+		stage := project.Stages[stageName]
+		if stage == nil {
+			continue
+		}
+		result.Projects[projectName] = &Project{
+			Name:     project.Name,
+			Services: stage.Services,
+			Stages: map[string]*Stage{
+				stageName: stage,
+			},
+			KeptnServices: project.KeptnServices,
+		}
+	}
+	return result
+}
+
+// merge adds the counts of the given service statistics to this service
+func (svc *Service) merge(other *Service) {
+	svc.ensureMapsExist()
+	for eventType, count := range other.Events {
+		svc.Events[eventType] = svc.Events[eventType] + count
+	}
+	if other.ExecutedSequences > 0 {
+		svc.ExecutedSequences = svc.ExecutedSequences + other.ExecutedSequences
+	}
+	for keptnServiceName, keptnService := range other.KeptnServiceExecutions {
+		for eventType, count := range keptnService.Executions {
+			targetKeptnService := svc.ensureKeptnServiceExists(keptnServiceName)
+			targetKeptnService.Executions[eventType] = targetKeptnService.Executions[eventType] + count
+		}
+		if len(keptnService.Durations) > 0 {
+			targetKeptnService := svc.ensureKeptnServiceExists(keptnServiceName)
+			targetKeptnService.Durations = mergeDurations(targetKeptnService.Durations, keptnService.Durations)
+		}
+		for taskType, taskResults := range keptnService.TaskResults {
+			targetTaskResults := svc.ensureKeptnServiceExists(keptnServiceName).ensureTaskResultsExist(taskType)
+			for result, count := range taskResults.Results {
+				targetTaskResults.Results[result] = targetTaskResults.Results[result] + count
+			}
+			for status, count := range taskResults.Statuses {
+				targetTaskResults.Statuses[status] = targetTaskResults.Statuses[status] + count
+			}
+		}
+	}
+	for eventType, sequenceExecutions := range other.ExecutedSequencesPerType {
+		svc.ExecutedSequencesPerType[eventType] = svc.ExecutedSequencesPerType[eventType] + sequenceExecutions
+	}
+	if other.UniqueSequences != nil {
+		if svc.UniqueSequences == nil {
+			svc.UniqueSequences = NewHyperLogLog()
+		}
+		svc.UniqueSequences.Merge(other.UniqueSequences)
+	}
+	if len(other.SequenceDurations) > 0 {
+		svc.SequenceDurations = mergeDurations(svc.SequenceDurations, other.SequenceDurations)
+	}
+	if other.Evaluations != nil {
+		if svc.Evaluations == nil {
+			svc.Evaluations = NewEvaluationStatistics()
+		}
+		svc.Evaluations.Merge(other.Evaluations)
+	}
+	if other.Dora != nil {
+		svc.ensureDoraExists().Merge(other.Dora)
+	}
+	if other.Remediation != nil {
+		svc.ensureRemediationExists().Merge(other.Remediation)
+	}
+	if other.Approvals != nil {
+		svc.ensureApprovalsExist().Merge(other.Approvals)
+	}
+	if len(other.Triggers) > 0 {
+		svc.Triggers = MergeTriggers(svc.Triggers, other.Triggers)
+	}
+	for labelKey, values := range other.Labels {
+		for labelValue, counts := range values {
+			svc.addLabelCount(labelKey, labelValue, *counts)
+		}
+	}
+}
+
+func mergeDurations(target, durations map[string]*DurationStatistics) map[string]*DurationStatistics {
+	if target == nil {
+		target = map[string]*DurationStatistics{}
+	}
+	for durationType, duration := range durations {
+		if target[durationType] == nil {
+			target[durationType] = NewDurationStatistics()
+		}
+		target[durationType].Merge(duration)
+	}
+	return target
+}
+
+// withTaskNames returns a copy of the statistics of a service stored by a previous version of the service, in which the executions, sequences,
+// durations and task results are counted per task name instead of per event type, so that they can be merged with the statistics of the current version
+func (svc *Service) withTaskNames() *Service {
+	result := *svc
+	result.ExecutedSequencesPerType = normalizeCounts(svc.ExecutedSequencesPerType)
+	result.SequenceDurations = normalizeDurations(svc.SequenceDurations)
+	result.KeptnServiceExecutions = map[string]*KeptnService{}
+	for keptnServiceName, keptnService := range svc.KeptnServiceExecutions {
+		normalized := &KeptnService{
+			Name:       keptnService.Name,
+			Executions: normalizeCounts(keptnService.Executions),
+			Durations:  normalizeDurations(keptnService.Durations),
+		}
+		for taskType, taskResults := range keptnService.TaskResults {
+			targetTaskResults := normalized.ensureTaskResultsExist(normalizeTaskName(taskType))
+			for result, count := range taskResults.Results {
+				targetTaskResults.Results[result] = targetTaskResults.Results[result] + count
+			}
+			for status, count := range taskResults.Statuses {
+				targetTaskResults.Statuses[status] = targetTaskResults.Statuses[status] + count
+			}
+		}
+		result.KeptnServiceExecutions[keptnServiceName] = normalized
+	}
+	return &result
+}
+
+func normalizeCounts(counts map[string]int) map[string]int {
+	result := map[string]int{}
+	for key, count := range counts {
+		taskName := normalizeTaskName(key)
+		result[taskName] = result[taskName] + count
+	}
+	return result
+}
+
+func normalizeDurations(durations map[string]*DurationStatistics) map[string]*DurationStatistics {
+	if durations == nil {
+		return nil
+	}
+	result := map[string]*DurationStatistics{}
+	for key, duration := range durations {
+		taskName := normalizeTaskName(key)
+		if result[taskName] == nil {
+			result[taskName] = NewDurationStatistics()
+		}
+		result[taskName].Merge(duration)
+	}
+	return result
+}
+
+// WithProjectTotals returns a copy of the statistics in which the services of each project contain the totals of all stages
+func (s Statistics) WithProjectTotals() Statistics {
+	return MergeStatistics(Statistics{From: s.From, To: s.To, TaskNames: true}, []Statistics{s})
+}
+
+// MergeStatistics adds the given statistics to the target. In the result, the services of each project contain the totals of all stages,
+// and the statistics of previous versions are counted per task name
 func MergeStatistics(target Statistics, statistics []Statistics) Statistics {
+	if target.StagesOnly || (!target.TaskNames && len(target.Projects) > 0) {
+		target = target.WithProjectTotals()
+	}
+	target.TaskNames = true
 	for _, stats := range statistics {
+		for dimension, count := range stats.Overflows {
+			target.IncreaseOverflowCount(dimension, count)
+		}
+		for dimension, count := range stats.FilteredEvents {
+			target.IncreaseFilteredEventCount(dimension, count)
+		}
 		for projectName, project := range stats.Projects {
 			target.ensureProjectExists(projectName)
+			target.Projects[projectName].mergeKeptnServices(project)
 			for serviceName, service := range project.Services {
-				for eventType, count := range service.Events {
-					target.IncreaseEventTypeCount(projectName, serviceName, eventType, count)
-				}
-				if service.ExecutedSequences > 0 {
-					target.IncreaseExecutedSequencesCount(projectName, serviceName, service.ExecutedSequences)
+				if !stats.TaskNames {
+					service = service.withTaskNames()
 				}
-				for keptnServiceName, keptnService := range service.KeptnServiceExecutions {
-					for eventType, count := range keptnService.Executions {
-						target.IncreaseKeptnServiceExecutionCount(projectName, serviceName, keptnServiceName, eventType, count)
+				target.ensureProjectAndServiceExist(projectName, serviceName)
+				target.Projects[projectName].Services[serviceName].merge(service)
+			}
+			for stageName, stage := range project.Stages {
+				for serviceName, service := range stage.Services {
+					if !stats.TaskNames {
+						service = service.withTaskNames()
+					}
+					target.ensureStageAndServiceExist(projectName, stageName, serviceName)
+					target.Projects[projectName].Stages[stageName].Services[serviceName].merge(service)
+					if stats.StagesOnly {
+						target.ensureProjectAndServiceExist(projectName, serviceName)
+						target.Projects[projectName].Services[serviceName].merge(service)
 					}
-				}
-				for eventType, sequenceExecutions := range service.ExecutedSequencesPerType {
-					target.IncreaseExecutedSequenceCountForType(projectName, serviceName, eventType, sequenceExecutions)
 				}
 			}
 		}
@@ -158,7 +158,7 @@ func TestStatistics_IncreaseExecutedSequencesCount(t *testing.T) {
 				Projects: tt.fields.Projects,
 			}
 
-			s.IncreaseExecutedSequencesCount(tt.args.projectName, tt.args.serviceName, tt.args.increment)
+			s.IncreaseExecutedSequencesCount(tt.args.projectName, "", tt.args.serviceName, tt.args.increment)
 			result := s.Projects[tt.args.projectName].Services[tt.args.serviceName].ExecutedSequences
 			if result != tt.wantResult {
 				t.Errorf("Statistics.IncreaseExecutedSequencesCount(): want %d, got %d", tt.wantResult, result)
@@ -264,7 +264,7 @@ func TestStatistics_IncreaseEventTypeCount(t *testing.T) {
 				Projects: tt.fields.Projects,
 			}
 
-			s.IncreaseEventTypeCount(tt.args.projectName, tt.args.serviceName, tt.args.eventType, tt.args.increment)
+			s.IncreaseEventTypeCount(tt.args.projectName, "", tt.args.serviceName, tt.args.eventType, tt.args.increment)
 			result := s.Projects[tt.args.projectName].Services[tt.args.serviceName].Events[tt.args.eventType]
 			if result != tt.wantResult {
 				t.Errorf("Statistics.IncreaseEventTypeCount(): want %d, got %d", tt.wantResult, result)
@@ -367,8 +367,9 @@ func TestMergeStatistics(t *testing.T) {
 				},
 			},
 			want: Statistics{
-				From: time.Time{},
-				To:   time.Time{},
+				From:      time.Time{},
+				To:        time.Time{},
+				TaskNames: true,
 				Projects: map[string]*Project{
 					"my-project": {
 						Name: "my-project",
@@ -410,6 +411,90 @@ func TestMergeStatistics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge statistics with stages and buckets without stage information",
+			args: args{
+				target: Statistics{},
+				statistics: []Statistics{
+					{
+						Projects: map[string]*Project{
+							"my-project": {
+								Name: "my-project",
+								Services: map[string]*Service{
+									"my-service": {
+										Name: "my-service",
+										Events: map[string]int{
+											"my-type": 1,
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Projects: map[string]*Project{
+							"my-project": {
+								Name: "my-project",
+								Services: map[string]*Service{
+									"my-service": {
+										Name: "my-service",
+										Events: map[string]int{
+											"my-type": 2,
+										},
+									},
+								},
+								Stages: map[string]*Stage{
+									"dev": {
+										Name: "dev",
+										Services: map[string]*Service{
+											"my-service": {
+												Name: "my-service",
+												Events: map[string]int{
+													"my-type": 2,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Statistics{
+				TaskNames: true,
+				Projects: map[string]*Project{
+					"my-project": {
+						Name: "my-project",
+						Services: map[string]*Service{
+							"my-service": {
+								Name: "my-service",
+								Events: map[string]int{
+									"my-type": 3,
+								},
+								KeptnServiceExecutions:   map[string]*KeptnService{},
+								ExecutedSequencesPerType: map[string]int{},
+							},
+						},
+						Stages: map[string]*Stage{
+							"dev": {
+								Name: "dev",
+								Services: map[string]*Service{
+									"my-service": {
+										Name: "my-service",
+										Events: map[string]int{
+											"my-type": 2,
+										},
+										KeptnServiceExecutions:   map[string]*KeptnService{},
+										ExecutedSequencesPerType: map[string]int{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -529,7 +614,7 @@ func TestStatistics_IncreaseKeptnServiceExecutionCount(t *testing.T) {
 				Projects: tt.fields.Projects,
 			}
 
-			s.IncreaseKeptnServiceExecutionCount(tt.args.projectName, tt.args.serviceName, tt.args.keptnServiceName, tt.args.eventType, tt.args.increment)
+			s.IncreaseKeptnServiceExecutionCount(tt.args.projectName, "", tt.args.serviceName, tt.args.keptnServiceName, tt.args.eventType, tt.args.increment)
 			result := s.Projects[tt.args.projectName].Services[tt.args.serviceName].KeptnServiceExecutions[tt.args.keptnServiceName].Executions[tt.args.eventType]
 			if result != tt.wantResult {
 				t.Errorf("Statistics.IncreaseEventTypeCount(): want %d, got %d", tt.wantResult, result)
@@ -537,3 +622,177 @@ func TestStatistics_IncreaseKeptnServiceExecutionCount(t *testing.T) {
 		})
 	}
 }
+
+func TestStatistics_IncreaseEventTypeCountForStage(t *testing.T) {
+	s := Statistics{}
+
+	s.IncreaseEventTypeCount("my-project", "dev", "my-service", "my-type", 1)
+	s.IncreaseEventTypeCount("my-project", "production", "my-service", "my-type", 2)
+	s.IncreaseEventTypeCount("my-project", "", "my-service", "my-type", 4)
+
+	if !s.StagesOnly {
+		t.Error("Statistics.IncreaseEventTypeCount(): events with a stage must only be counted per stage")
+	}
+	if got := s.Projects["my-project"].Services["my-service"].Events["my-type"]; got != 4 {
+		t.Errorf("Statistics.IncreaseEventTypeCount(): want count %d for events without a stage, got %d", 4, got)
+	}
+	if got := s.WithProjectTotals().Projects["my-project"].Services["my-service"].Events["my-type"]; got != 7 {
+		t.Errorf("Statistics.WithProjectTotals(): want project-wide count %d, got %d", 7, got)
+	}
+	if got := s.Projects["my-project"].Stages["dev"].Services["my-service"].Events["my-type"]; got != 1 {
+		t.Errorf("Statistics.IncreaseEventTypeCount(): want count %d for stage dev, got %d", 1, got)
+	}
+	if got := s.Projects["my-project"].Stages["production"].Services["my-service"].Events["my-type"]; got != 2 {
+		t.Errorf("Statistics.IncreaseEventTypeCount(): want count %d for stage production, got %d", 2, got)
+	}
+	if len(s.Projects["my-project"].Stages) != 2 {
+		t.Errorf("Statistics.IncreaseEventTypeCount(): want %d stages, got %d", 2, len(s.Projects["my-project"].Stages))
+	}
+}
+
+func TestStatistics_FilterStage(t *testing.T) {
+	s := Statistics{}
+	s.IncreaseEventTypeCount("my-project", "dev", "my-service", "my-type", 1)
+	s.IncreaseEventTypeCount("my-project", "production", "my-service", "my-type", 2)
+	s.IncreaseEventTypeCount("my-project-2", "dev", "my-service", "my-type", 3)
+	s.IncreaseEventTypeCount("my-legacy-project", "", "my-service", "my-type", 3)
+	s.IncreaseOverflowCount("service", 1)
+	s.IncreaseFilteredEventCount("project", 2)
+	s.AddKeptnServiceVersion("my-project", "helm-service", "0.8.0", time.Now())
+	s = s.WithProjectTotals()
+
+	got := s.FilterStage("production")
+
+	if len(got.Projects) != 1 || got.Projects["my-project"] == nil {
+		t.Fatalf("Statistics.FilterStage(): expected only my-project to be included, got %v", got.Projects)
+	}
+	if count := got.Projects["my-project"].Services["my-service"].Events["my-type"]; count != 2 {
+		t.Errorf("Statistics.FilterStage(): want count %d, got %d", 2, count)
+	}
+	if got.Projects["my-project"].Stages["dev"] != nil {
+		t.Error("Statistics.FilterStage(): stage dev should not be included")
+	}
+	if count := s.Projects["my-project"].Services["my-service"].Events["my-type"]; count != 3 {
+		t.Errorf("Statistics.FilterStage(): original statistics must not be modified, want count %d, got %d", 3, count)
+	}
+	if got.Overflows["service"] != 1 || got.FilteredEvents["project"] != 2 || got.Projects["my-project"].KeptnServices["helm-service"] == nil {
+		t.Errorf("Statistics.FilterStage(): overflows, filtered events and Keptn services must be kept, got %v, %v, %v", got.Overflows, got.FilteredEvents, got.Projects["my-project"].KeptnServices)
+	}
+}
+
+func TestMergeStatistics_StagesOnly(t *testing.T) {
+	bucket := Statistics{}
+	bucket.IncreaseEventTypeCount("my-project", "dev", "my-service", "my-type", 1)
+	bucket.IncreaseEventTypeCount("my-project", "", "my-service", "my-type", 2)
+
+	// buckets without StagesOnly contain the totals of all stages in the services of a project
+	legacyBucket := Statistics{
+		Projects: map[string]*Project{
+			"my-project": {
+				Name: "my-project",
+				Services: map[string]*Service{
+					"my-service": {Name: "my-service", Events: map[string]int{"my-type": 3}},
+				},
+				Stages: map[string]*Stage{
+					"dev": {
+						Name: "dev",
+						Services: map[string]*Service{
+							"my-service": {Name: "my-service", Events: map[string]int{"my-type": 3}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := MergeStatistics(Statistics{}, []Statistics{bucket, legacyBucket})
+
+	if got.StagesOnly {
+		t.Error("MergeStatistics(): merged statistics must contain the totals of the projects")
+	}
+	if count := got.Projects["my-project"].Services["my-service"].Events["my-type"]; count != 6 {
+		t.Errorf("MergeStatistics(): want project-wide count %d, got %d", 6, count)
+	}
+	if count := got.Projects["my-project"].Stages["dev"].Services["my-service"].Events["my-type"]; count != 4 {
+		t.Errorf("MergeStatistics(): want count %d for stage dev, got %d", 4, count)
+	}
+	if count := MergeStatistics(bucket, []Statistics{legacyBucket}).Projects["my-project"].Services["my-service"].Events["my-type"]; count != 6 {
+		t.Errorf("MergeStatistics(): want project-wide count %d when merging into a bucket, got %d", 6, count)
+	}
+}
+
+func TestStatistics_IncreaseTaskResultCount(t *testing.T) {
+	s := Statistics{}
+	s.IncreaseTaskResultCount("my-project", "dev", "my-service", "helm-service", "sh.keptn.event.deployment", "pass", "succeeded", 1)
+	s.IncreaseTaskResultCount("my-project", "dev", "my-service", "helm-service", "sh.keptn.event.deployment", "fail", "", 1)
+
+	merged := MergeStatistics(Statistics{}, []Statistics{s, s})
+
+	want := &TaskResults{
+		Results: map[string]int{
+			"pass": 2,
+			"fail": 2,
+		},
+		Statuses: map[string]int{
+			"succeeded": 2,
+		},
+	}
+	got := merged.Projects["my-project"].Services["my-service"].KeptnServiceExecutions["helm-service"].TaskResults["sh.keptn.event.deployment"]
+	if diff := deep.Equal(got, want); len(diff) > 0 {
+		t.Error("Statistics.IncreaseTaskResultCount(): did not get expected value")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+	gotStage := merged.Projects["my-project"].Stages["dev"].Services["my-service"].KeptnServiceExecutions["helm-service"].TaskResults["sh.keptn.event.deployment"]
+	if diff := deep.Equal(gotStage, want); len(diff) > 0 {
+		t.Error("Statistics.IncreaseTaskResultCount(): did not get expected value for stage")
+		for _, d := range diff {
+			t.Log(d)
+		}
+	}
+}
+
+func TestMergeStatistics_TaskNames(t *testing.T) {
+	bucket := Statistics{}
+	bucket.IncreaseKeptnServiceExecutionCount("my-project", "dev", "my-service", "helm-service", "deployment", 1)
+	bucket.IncreaseExecutedSequenceCountForType("my-project", "dev", "my-service", "delivery", 1)
+
+	// previous versions counted executions and sequences per event type
+	legacyService := &Service{
+		Name:                     "my-service",
+		ExecutedSequencesPerType: map[string]int{"sh.keptn.event.dev.delivery": 2},
+		KeptnServiceExecutions: map[string]*KeptnService{
+			"helm-service": {
+				Name:       "helm-service",
+				Executions: map[string]int{"sh.keptn.event.deployment": 2, "sh.keptn.events.deployment-finished": 3},
+				TaskResults: map[string]*TaskResults{
+					"sh.keptn.event.deployment": {Results: map[string]int{"pass": 2}, Statuses: map[string]int{}},
+				},
+			},
+		},
+	}
+	legacyBucket := Statistics{
+		Projects: map[string]*Project{
+			"my-project": {
+				Name:     "my-project",
+				Services: map[string]*Service{"my-service": legacyService},
+			},
+		},
+	}
+
+	got := MergeStatistics(Statistics{}, []Statistics{bucket, legacyBucket}).Projects["my-project"].Services["my-service"]
+
+	if diff := deep.Equal(got.KeptnServiceExecutions["helm-service"].Executions, map[string]int{"deployment": 6}); len(diff) > 0 {
+		t.Errorf("MergeStatistics(): unexpected executions %v", got.KeptnServiceExecutions["helm-service"].Executions)
+	}
+	if diff := deep.Equal(got.ExecutedSequencesPerType, map[string]int{"delivery": 3}); len(diff) > 0 {
+		t.Errorf("MergeStatistics(): unexpected executed sequences %v", got.ExecutedSequencesPerType)
+	}
+	if taskResults := got.KeptnServiceExecutions["helm-service"].TaskResults["deployment"]; taskResults == nil || taskResults.Results["pass"] != 2 {
+		t.Errorf("MergeStatistics(): unexpected task results %v", got.KeptnServiceExecutions["helm-service"].TaskResults)
+	}
+	if legacyService.KeptnServiceExecutions["helm-service"].Executions["sh.keptn.event.deployment"] != 2 {
+		t.Error("MergeStatistics(): the merged statistics have been modified")
+	}
+}
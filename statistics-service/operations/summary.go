@@ -0,0 +1,249 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SummaryGroupByOverall godoc
+const SummaryGroupByOverall = "overall"
+
+// SummaryGroupByProject godoc
+const SummaryGroupByProject = "project"
+
+// SummaryGroupByService godoc
+const SummaryGroupByService = "service"
+
+// GetSummaryParams godoc
+type GetSummaryParams struct {
+	// GetStatisticsParams contains the time frame and the filters. For summaries, GroupBy contains the level of the totals, i.e. overall, project or service
+	GetStatisticsParams
+	// IncludeEvents contains a comma-separated list of the event types that define an automation unit. If empty, all event types are included
+	IncludeEvents string `form:"includeEvents" json:"includeEvents"`
+	// IncludeServices contains a comma-separated list of the Keptn services that define an automation unit. If empty, all Keptn services are included
+	IncludeServices string `form:"includeServices" json:"includeServices"`
+	// ExcludeProjects contains a comma-separated list of projects that are not included in the summary
+	ExcludeProjects string `form:"excludeProjects" json:"excludeProjects"`
+}
+
+// SummaryOptions decides which Keptn service executions are counted as automation units. Names are compared case-insensitively
+type SummaryOptions struct {
+	// IncludeEvents godoc
+	IncludeEvents []string
+	// IncludeServices godoc
+	IncludeServices []string
+	// ExcludeProjects godoc
+	ExcludeProjects []string
+}
+
+// NewSummaryOptions creates the options from comma-separated lists. The value 'all' includes all event types or Keptn services
+func NewSummaryOptions(includeEvents, includeServices, excludeProjects string) SummaryOptions {
+	return SummaryOptions{
+		IncludeEvents:   splitSummaryList(includeEvents),
+		IncludeServices: splitSummaryList(includeServices),
+		ExcludeProjects: splitSummaryList(excludeProjects),
+	}
+}
+
+func splitSummaryList(list string) []string {
+	list = strings.TrimSpace(strings.ToLower(list))
+	if list == "" || list == "all" {
+		return []string{}
+	}
+	result := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func containsIgnoreCase(list []string, value string) bool {
+	for _, item := range list {
+		if strings.ToLower(item) == strings.ToLower(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// IncludesProject godoc
+func (o SummaryOptions) IncludesProject(projectName string) bool {
+	return !containsIgnoreCase(o.ExcludeProjects, projectName)
+}
+
+// IncludesKeptnService godoc
+func (o SummaryOptions) IncludesKeptnService(keptnServiceName string) bool {
+	return len(o.IncludeServices) == 0 || containsIgnoreCase(o.IncludeServices, keptnServiceName)
+}
+
+// IncludesEvent godoc
+// Both the included events and the given event type are normalized to their task name, so that filters on legacy
+// or full event types (e.g. sh.keptn.events.deployment-finished) match the task names of newer statistics
+func (o SummaryOptions) IncludesEvent(eventType string) bool {
+	if len(o.IncludeEvents) == 0 || containsIgnoreCase(o.IncludeEvents, eventType) {
+		return true
+	}
+	taskName := GetTaskName(eventType)
+	for _, item := range o.IncludeEvents {
+		if strings.EqualFold(GetTaskName(item), taskName) {
+			return true
+		}
+	}
+	return false
+}
+
+// SummaryGroup contains the automation units of an overall, project or service summary
+type SummaryGroup struct {
+	// AutomationUnits contains the number of executions of the included Keptn services and event types
+	AutomationUnits int
+	// KeptnServiceExecutions contains the number of included executions per Keptn service and event type
+	KeptnServiceExecutions map[string]map[string]int
+}
+
+func newSummaryGroup() *SummaryGroup {
+	return &SummaryGroup{
+		KeptnServiceExecutions: map[string]map[string]int{},
+	}
+}
+
+func (g *SummaryGroup) add(keptnServiceName, eventType string, count int) {
+	g.AutomationUnits = g.AutomationUnits + count
+	if g.KeptnServiceExecutions[keptnServiceName] == nil {
+		g.KeptnServiceExecutions[keptnServiceName] = map[string]int{}
+	}
+	g.KeptnServiceExecutions[keptnServiceName][eventType] = g.KeptnServiceExecutions[keptnServiceName][eventType] + count
+}
+
+// Summary computes the automation units of one or more statistics responses on all levels.
+// It is used by the summary endpoint as well as by the CLI, so that both compute the same totals
+type Summary struct {
+	options SummaryOptions
+	// Overall godoc
+	Overall *SummaryGroup
+	// Projects contains the summary per project
+	Projects map[string]*SummaryGroup
+	// Services contains the summary per project and service
+	Services map[string]map[string]*SummaryGroup
+}
+
+// NewSummary godoc
+func NewSummary(options SummaryOptions) *Summary {
+	return &Summary{
+		options:  options,
+		Overall:  newSummaryGroup(),
+		Projects: map[string]*SummaryGroup{},
+		Services: map[string]map[string]*SummaryGroup{},
+	}
+}
+
+// Add adds the Keptn service executions of the statistics response
+func (s *Summary) Add(response GetStatisticsResponse) {
+	for _, project := range response.Projects {
+		if !s.options.IncludesProject(project.Name) {
+			continue
+		}
+		if s.Projects[project.Name] == nil {
+			s.Projects[project.Name] = newSummaryGroup()
+			s.Services[project.Name] = map[string]*SummaryGroup{}
+		}
+		for _, service := range project.Services {
+			if s.Services[project.Name][service.Name] == nil {
+				s.Services[project.Name][service.Name] = newSummaryGroup()
+			}
+			for _, execution := range service.KeptnServiceExecutions {
+				if !s.options.IncludesKeptnService(execution.Name) {
+					continue
+				}
+				for _, eventTypeExecution := range execution.Executions {
+					if !s.options.IncludesEvent(eventTypeExecution.Type) {
+						continue
+					}
+					s.Overall.add(execution.Name, eventTypeExecution.Type, eventTypeExecution.Count)
+					s.Projects[project.Name].add(execution.Name, eventTypeExecution.Type, eventTypeExecution.Count)
+					s.Services[project.Name][service.Name].add(execution.Name, eventTypeExecution.Type, eventTypeExecution.Count)
+				}
+			}
+		}
+	}
+}
+
+// GetSummaryResponse godoc
+type GetSummaryResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// GroupBy godoc
+	GroupBy string `json:"groupBy" bson:"groupBy"`
+	// Groups godoc
+	Groups []GetSummaryResponseGroup `json:"groups" bson:"groups"`
+}
+
+// GetSummaryResponseGroup godoc
+type GetSummaryResponseGroup struct {
+	// Project is empty for the overall summary
+	Project string `json:"project,omitempty" bson:"project,omitempty"`
+	// Service is only set for service summaries
+	Service string `json:"service,omitempty" bson:"service,omitempty"`
+	// AutomationUnits godoc
+	AutomationUnits int `json:"automationUnits" bson:"automationUnits"`
+	// KeptnServiceExecutions godoc
+	KeptnServiceExecutions []GetSummaryResponseExecution `json:"keptnServiceExecutions" bson:"keptnServiceExecutions"`
+}
+
+// GetSummaryResponseExecution godoc
+type GetSummaryResponseExecution struct {
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Type godoc
+	Type string `json:"type" bson:"type"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+}
+
+// ToResponse returns the groups of the given level
+func (s *Summary) ToResponse(groupBy string) (GetSummaryResponse, error) {
+	result := GetSummaryResponse{
+		GroupBy: groupBy,
+		Groups:  []GetSummaryResponseGroup{},
+	}
+	switch groupBy {
+	case SummaryGroupByOverall:
+		result.Groups = append(result.Groups, s.Overall.toResponse("", ""))
+	case SummaryGroupByProject:
+		for projectName, project := range s.Projects {
+			result.Groups = append(result.Groups, project.toResponse(projectName, ""))
+		}
+	case SummaryGroupByService:
+		for projectName, services := range s.Services {
+			for serviceName, service := range services {
+				result.Groups = append(result.Groups, service.toResponse(projectName, serviceName))
+			}
+		}
+	default:
+		return GetSummaryResponse{}, fmt.Errorf("unsupported groupBy value '%s'. Supported values are: overall, project, service", groupBy)
+	}
+	return result, nil
+}
+
+func (g *SummaryGroup) toResponse(projectName, serviceName string) GetSummaryResponseGroup {
+	result := GetSummaryResponseGroup{
+		Project:                projectName,
+		Service:                serviceName,
+		AutomationUnits:        g.AutomationUnits,
+		KeptnServiceExecutions: []GetSummaryResponseExecution{},
+	}
+	for keptnServiceName, executions := range g.KeptnServiceExecutions {
+		for eventType, count := range executions {
+			result.KeptnServiceExecutions = append(result.KeptnServiceExecutions, GetSummaryResponseExecution{
+				Name:  keptnServiceName,
+				Type:  eventType,
+				Count: count,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	newService := func(name string, executions ...GetStatisticsResponseKeptnService) GetStatisticsResponseService {
+		return GetStatisticsResponseService{Name: name, KeptnServiceExecutions: executions}
+	}
+	newExecution := func(keptnServiceName string, executions ...GetStatisticsResponseEvent) GetStatisticsResponseKeptnService {
+		return GetStatisticsResponseKeptnService{Name: keptnServiceName, Executions: executions}
+	}
+	response := GetStatisticsResponse{
+		Projects: []GetStatisticsResponseProject{
+			{
+				Name: "sockshop",
+				Services: []GetStatisticsResponseService{
+					newService("carts",
+						newExecution("helm-service", GetStatisticsResponseEvent{Type: "deployment", Count: 3}),
+						newExecution("jmeter-service", GetStatisticsResponseEvent{Type: "test", Count: 2}, GetStatisticsResponseEvent{Type: "get-sli", Count: 5}),
+					),
+					newService("orders", newExecution("Helm-Service", GetStatisticsResponseEvent{Type: "Deployment", Count: 1})),
+				},
+			},
+			{
+				Name:     "test-project",
+				Services: []GetStatisticsResponseService{newService("carts", newExecution("helm-service", GetStatisticsResponseEvent{Type: "deployment", Count: 7}))},
+			},
+		},
+	}
+
+	summary := NewSummary(NewSummaryOptions("deployment,test", "all", "test-project"))
+	summary.Add(response)
+	summary.Add(response)
+
+	if summary.Overall.AutomationUnits != 12 {
+		t.Errorf("Summary: want %d automation units, got %d", 12, summary.Overall.AutomationUnits)
+	}
+	if summary.Projects["test-project"] != nil {
+		t.Error("Summary: excluded project has been included")
+	}
+	if got := summary.Services["sockshop"]["carts"]; got.AutomationUnits != 10 || got.KeptnServiceExecutions["jmeter-service"]["get-sli"] != 0 {
+		t.Errorf("Summary: unexpected summary of service carts: %+v", got)
+	}
+
+	got, err := summary.ToResponse(SummaryGroupByService)
+	if err != nil || len(got.Groups) != 2 {
+		t.Errorf("ToResponse(): unexpected groups %v, error %v", got.Groups, err)
+	}
+	if _, err := summary.ToResponse("stage"); err == nil {
+		t.Error("ToResponse(): expected an error for an unsupported groupBy value")
+	}
+}
+
+func TestSummaryOptions_IncludesEventNormalizesTaskNames(t *testing.T) {
+	tests := []struct {
+		includeEvents string
+		eventType     string
+		want          bool
+	}{
+		{includeEvents: "deployment", eventType: "deployment", want: true},
+		{includeEvents: "Deployment", eventType: "deployment", want: true},
+		{includeEvents: "sh.keptn.events.deployment-finished", eventType: "deployment", want: true},
+		{includeEvents: "sh.keptn.event.deployment.finished", eventType: "deployment", want: true},
+		{includeEvents: "sh.keptn.event.deployment", eventType: "deployment", want: true},
+		{includeEvents: "deployment", eventType: "sh.keptn.events.deployment-finished", want: true},
+		{includeEvents: "sh.keptn.events.tests-finished", eventType: "test", want: true},
+		{includeEvents: "sh.keptn.events.deployment-finished", eventType: "test", want: false},
+		{includeEvents: "test", eventType: "sh.keptn.events.deployment-finished", want: false},
+		{includeEvents: "all", eventType: "anything", want: true},
+	}
+	for _, tt := range tests {
+		options := NewSummaryOptions(tt.includeEvents, "all", "")
+		if got := options.IncludesEvent(tt.eventType); got != tt.want {
+			t.Errorf("IncludesEvent(%s) with includeEvents=%s = %v, want %v", tt.eventType, tt.includeEvents, got, tt.want)
+		}
+	}
+}
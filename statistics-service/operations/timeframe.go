@@ -0,0 +1,238 @@
+package operations
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PeriodToday godoc
+const PeriodToday = "today"
+
+// PeriodYesterday godoc
+const PeriodYesterday = "yesterday"
+
+// PeriodThisWeek godoc
+const PeriodThisWeek = "thisWeek"
+
+// PeriodLastWeek godoc
+const PeriodLastWeek = "lastWeek"
+
+// PeriodThisMonth godoc
+const PeriodThisMonth = "thisMonth"
+
+// PeriodLastMonth godoc
+const PeriodLastMonth = "lastMonth"
+
+// PeriodThisYear godoc
+const PeriodThisYear = "thisYear"
+
+// PeriodLastYear godoc
+const PeriodLastYear = "lastYear"
+
+// maxUnixSecondsDigits is the number of digits of Unix timestamps in seconds until the year 2286
+const maxUnixSecondsDigits = 10
+
+// unixMillisecondsDigits is the number of digits of Unix timestamps in milliseconds between the years 2001 and 2286
+const unixMillisecondsDigits = 13
+
+// minUnixTimestamp and maxUnixTimestamp bound the plausible Unix timestamps, so that numbers like dates in the format
+// 20201001 are not silently interpreted as timestamps in 1970
+var (
+	minUnixTimestamp = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	maxUnixTimestamp = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// localTimestampLayouts contains the accepted layouts of timestamps without a time zone, which are interpreted in the requested time zone
+var localTimestampLayouts = []string{"2006-01-02T15:04:05", "2006-01-02"}
+
+// relativeTimestampPattern matches an anchor, e.g. now or startOfMonth, followed by any number of offsets, e.g. -7d or +1h
+var relativeTimestampPattern = regexp.MustCompile(`^(now|today|startOfDay|startOfWeek|startOfMonth|startOfYear)((?:[+-]\d+[a-zA-Z]+)*)$`)
+
+var relativeOffsetPattern = regexp.MustCompile(`([+-])(\d+)([a-zA-Z]+)`)
+
+// ResolveTimeFrame sets From and To based on the 'from', 'to', 'period' and 'timezone' query parameters.
+// If only 'from' is set, 'to' defaults to the current time
+func (p *GetStatisticsParams) ResolveTimeFrame(now time.Time) error {
+	location := time.UTC
+	if p.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(p.Timezone)
+		if err != nil {
+			return fmt.Errorf("unknown timezone %s", p.Timezone)
+		}
+	}
+	now = now.In(location)
+
+	if p.Period != "" {
+		if p.FromParam != "" || p.ToParam != "" {
+			return fmt.Errorf("'period' must not be combined with 'from' or 'to'")
+		}
+		from, to, err := GetPeriod(p.Period, now)
+		if err != nil {
+			return err
+		}
+		p.From = from
+		p.To = to
+		return nil
+	}
+
+	from, err := ParseTimestamp(p.FromParam, now)
+	if err != nil {
+		return fmt.Errorf("'from': %s", err.Error())
+	}
+	to, err := ParseTimestamp(p.ToParam, now)
+	if err != nil {
+		return fmt.Errorf("'to': %s", err.Error())
+	}
+	if !from.IsZero() && p.ToParam == "" {
+		to = now
+	}
+	p.From = from
+	p.To = to
+	return nil
+}
+
+// ParseTimestamp parses an RFC3339 timestamp, a Unix timestamp in seconds or milliseconds, or a relative expression, e.g. now-7d or startOfMonth.
+// Timestamps without a time zone and relative expressions are interpreted in the location of now. An empty value results in the zero time
+func ParseTimestamp(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if isDigits(value) {
+		return parseUnixTimestamp(value)
+	}
+	if timestamp, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return timestamp, nil
+	}
+	for _, layout := range localTimestampLayouts {
+		if timestamp, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return timestamp, nil
+		}
+	}
+	if matches := relativeTimestampPattern.FindStringSubmatch(value); matches != nil {
+		return parseRelativeTimestamp(matches[1], matches[2], now)
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %s: expected an RFC3339 timestamp (e.g. 2020-10-01T00:00:00Z), a Unix timestamp in seconds or milliseconds, or a relative expression (e.g. now-7d or startOfMonth)", value)
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseUnixTimestamp interprets timestamps with up to 10 digits as seconds and timestamps with 13 digits as milliseconds.
+// Any other length can not be interpreted unambiguously, and timestamps outside of the years 2000 to 2099 are rejected as implausible
+func parseUnixTimestamp(value string) (time.Time, error) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Unix timestamp %s", value)
+	}
+	var timestamp time.Time
+	switch {
+	case len(value) <= maxUnixSecondsDigits:
+		timestamp = time.Unix(number, 0).UTC()
+	case len(value) == unixMillisecondsDigits:
+		timestamp = time.Unix(0, number*int64(time.Millisecond)).UTC()
+	default:
+		return time.Time{}, fmt.Errorf("ambiguous Unix timestamp %s: use seconds (up to %d digits) or milliseconds (%d digits)", value, maxUnixSecondsDigits, unixMillisecondsDigits)
+	}
+	if timestamp.Before(minUnixTimestamp) || !timestamp.Before(maxUnixTimestamp) {
+		return time.Time{}, fmt.Errorf("implausible Unix timestamp %s (%s): use a timestamp between %d and %d, or an RFC3339 timestamp or date (e.g. 2020-10-01)",
+			value, timestamp.Format(time.RFC3339), minUnixTimestamp.Unix(), maxUnixTimestamp.Unix()-1)
+	}
+	return timestamp, nil
+}
+
+func parseRelativeTimestamp(anchor, offsets string, now time.Time) (time.Time, error) {
+	timestamp := now
+	switch anchor {
+	case "today", "startOfDay":
+		timestamp = startOfDay(now)
+	case "startOfWeek":
+		timestamp = startOfWeek(now)
+	case "startOfMonth":
+		timestamp = startOfMonth(now)
+	case "startOfYear":
+		timestamp = startOfYear(now)
+	}
+	for _, offset := range relativeOffsetPattern.FindAllStringSubmatch(offsets, -1) {
+		amount, err := strconv.Atoi(offset[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid offset %s", offset[0])
+		}
+		if offset[1] == "-" {
+			amount = -amount
+		}
+		switch offset[3] {
+		case "s":
+			timestamp = timestamp.Add(time.Duration(amount) * time.Second)
+		case "m":
+			timestamp = timestamp.Add(time.Duration(amount) * time.Minute)
+		case "h":
+			timestamp = timestamp.Add(time.Duration(amount) * time.Hour)
+		case "d":
+			timestamp = timestamp.AddDate(0, 0, amount)
+		case "w":
+			timestamp = timestamp.AddDate(0, 0, 7*amount)
+		case "M":
+			timestamp = timestamp.AddDate(0, amount, 0)
+		case "y":
+			timestamp = timestamp.AddDate(amount, 0, 0)
+		default:
+			return time.Time{}, fmt.Errorf("unknown unit %s in %s: use s, m (minutes), h, d, w, M (months) or y", offset[3], offset[0])
+		}
+	}
+	return timestamp, nil
+}
+
+// GetPeriod returns the start and the end of a calendar period, e.g. lastMonth, in the location of now.
+// The end of a past period is the start of the following period, and the end of the current period is now
+func GetPeriod(period string, now time.Time) (time.Time, time.Time, error) {
+	switch period {
+	case PeriodToday:
+		return startOfDay(now), now, nil
+	case PeriodYesterday:
+		return startOfDay(now).AddDate(0, 0, -1), startOfDay(now), nil
+	case PeriodThisWeek:
+		return startOfWeek(now), now, nil
+	case PeriodLastWeek:
+		return startOfWeek(now).AddDate(0, 0, -7), startOfWeek(now), nil
+	case PeriodThisMonth:
+		return startOfMonth(now), now, nil
+	case PeriodLastMonth:
+		return startOfMonth(now).AddDate(0, -1, 0), startOfMonth(now), nil
+	case PeriodThisYear:
+		return startOfYear(now), now, nil
+	case PeriodLastYear:
+		return startOfYear(now).AddDate(-1, 0, 0), startOfYear(now), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown period %s: use %s", period, strings.Join([]string{
+		PeriodToday, PeriodYesterday, PeriodThisWeek, PeriodLastWeek, PeriodThisMonth, PeriodLastMonth, PeriodThisYear, PeriodLastYear,
+	}, ", "))
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the start of the week of the given time, where weeks start on Monday
+func startOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -daysSinceMonday)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func startOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
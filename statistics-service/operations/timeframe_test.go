@@ -0,0 +1,121 @@
+package operations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	// Thursday
+	now := time.Date(2020, 10, 15, 14, 30, 0, 0, location)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "1600656105", want: time.Unix(1600656105, 0)},
+		{value: "1600656105123", want: time.Unix(1600656105, 123*int64(time.Millisecond))},
+		{value: "160065610512", wantErr: true},
+		{value: "20201001", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "946684800", want: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "4102444800", wantErr: true},
+		{value: "0020201001000", wantErr: true},
+		{value: "2020-10-01T08:00:00Z", want: time.Date(2020, 10, 1, 8, 0, 0, 0, time.UTC)},
+		{value: "2020-10-01T08:00:00+02:00", want: time.Date(2020, 10, 1, 6, 0, 0, 0, time.UTC)},
+		{value: "2020-10-01", want: time.Date(2020, 10, 1, 0, 0, 0, 0, location)},
+		{value: "2020-10-01T08:00:00", want: time.Date(2020, 10, 1, 8, 0, 0, 0, location)},
+		{value: "now", want: now},
+		{value: "now-7d", want: now.AddDate(0, 0, -7)},
+		{value: "now-1h-30m", want: now.Add(-90 * time.Minute)},
+		{value: "startOfDay", want: time.Date(2020, 10, 15, 0, 0, 0, 0, location)},
+		{value: "startOfWeek", want: time.Date(2020, 10, 12, 0, 0, 0, 0, location)},
+		{value: "startOfMonth-1M", want: time.Date(2020, 9, 1, 0, 0, 0, 0, location)},
+		{value: "startOfYear+1y", want: time.Date(2021, 1, 1, 0, 0, 0, 0, location)},
+		{value: "now-7x", wantErr: true},
+		{value: "yesterday", wantErr: true},
+		{value: "10/01/2020", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPeriod(t *testing.T) {
+	now := time.Date(2020, 1, 15, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		period   string
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{period: PeriodYesterday, wantFrom: time.Date(2020, 1, 14, 0, 0, 0, 0, time.UTC), wantTo: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{period: PeriodThisWeek, wantFrom: time.Date(2020, 1, 13, 0, 0, 0, 0, time.UTC), wantTo: now},
+		{period: PeriodLastWeek, wantFrom: time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC), wantTo: time.Date(2020, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{period: PeriodLastMonth, wantFrom: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), wantTo: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{period: PeriodLastYear, wantFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), wantTo: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			from, to, err := GetPeriod(tt.period, now)
+			if err != nil {
+				t.Fatalf("GetPeriod(): unexpected error: %s", err.Error())
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("GetPeriod() = %v - %v, want %v - %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+	if _, _, err := GetPeriod("lastDecade", now); err == nil {
+		t.Error("GetPeriod(): expected an error for an unknown period")
+	}
+}
+
+func TestGetStatisticsParams_ResolveTimeFrame(t *testing.T) {
+	now := time.Date(2020, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	params := &GetStatisticsParams{FromParam: "now-1d"}
+	if err := params.ResolveTimeFrame(now); err != nil {
+		t.Fatalf("ResolveTimeFrame(): unexpected error: %s", err.Error())
+	}
+	if !params.From.Equal(now.AddDate(0, 0, -1)) || !params.To.Equal(now) {
+		t.Errorf("ResolveTimeFrame(): 'to' must default to now, got %v - %v", params.From, params.To)
+	}
+
+	params = &GetStatisticsParams{Period: PeriodLastMonth, Timezone: "Europe/Vienna"}
+	if err := params.ResolveTimeFrame(now); err != nil {
+		t.Fatalf("ResolveTimeFrame(): unexpected error: %s", err.Error())
+	}
+	if want := time.Date(2020, 8, 31, 22, 0, 0, 0, time.UTC); !params.From.Equal(want) {
+		t.Errorf("ResolveTimeFrame(): want 'from' %v, got %v", want, params.From)
+	}
+	if want := time.Date(2020, 9, 30, 22, 0, 0, 0, time.UTC); !params.To.Equal(want) {
+		t.Errorf("ResolveTimeFrame(): want 'to' %v, got %v", want, params.To)
+	}
+
+	params = &GetStatisticsParams{}
+	if err := params.ResolveTimeFrame(now); err != nil || !params.From.IsZero() || !params.To.IsZero() {
+		t.Errorf("ResolveTimeFrame(): an empty time frame must stay empty, got %v - %v, %v", params.From, params.To, err)
+	}
+
+	invalid := []GetStatisticsParams{
+		{Period: PeriodLastMonth, FromParam: "now-7d"},
+		{FromParam: "now", Timezone: "Mars/Olympus_Mons"},
+		{FromParam: "160065610512"},
+		{FromParam: "now", ToParam: "later"},
+	}
+	for _, params := range invalid {
+		if err := params.ResolveTimeFrame(now); err == nil {
+			t.Errorf("ResolveTimeFrame(): expected an error for %+v", params)
+		}
+	}
+}
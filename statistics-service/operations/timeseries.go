@@ -0,0 +1,187 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxTimeseriesDataPoints limits the number of data points of a time series
+const MaxTimeseriesDataPoints = 1000
+
+// TimeseriesCounterEvents godoc
+const TimeseriesCounterEvents = "events"
+
+// TimeseriesCounterKeptnServiceExecutions godoc
+const TimeseriesCounterKeptnServiceExecutions = "keptnServiceExecutions"
+
+// TimeseriesCounterExecutedSequences godoc
+const TimeseriesCounterExecutedSequences = "executedSequences"
+
+// TimeseriesCounterUniqueSequences godoc
+const TimeseriesCounterUniqueSequences = "uniqueSequences"
+
+// TimeseriesCounters contains the counters that can be selected for a time series
+var TimeseriesCounters = []string{TimeseriesCounterEvents, TimeseriesCounterKeptnServiceExecutions, TimeseriesCounterExecutedSequences, TimeseriesCounterUniqueSequences}
+
+// timeseriesSteps maps the supported steps to a function that returns the start of the n-th step after the given start of the time frame.
+// Steps are computed from the start of the time frame rather than from the previous step, so that shortened months do not shift the following steps
+var timeseriesSteps = map[string]func(time.Time, int) time.Time{
+	"1h": func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Hour) },
+	"1d": func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) },
+	"1w": func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+	"1M": addMonths,
+}
+
+// addMonths adds n months to the time, keeping its day of month. If the day does not exist in the resulting month, e.g. January 31 + 1 month, the last day of the month is used
+func addMonths(t time.Time, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, n, 0)
+	day := t.Day()
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// GetTimeseriesParams godoc
+type GetTimeseriesParams struct {
+	GetStatisticsParams
+	// Step contains the length of each data point, i.e. 1h, 1d, 1w or 1M
+	Step string `form:"step" json:"step"`
+	// Counters contains a comma-separated list of the counters to return. If empty, all counters are returned
+	Counters string `form:"counters" json:"counters"`
+}
+
+// GetTimeseriesResponse godoc
+type GetTimeseriesResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// Step godoc
+	Step string `json:"step" bson:"step"`
+	// DataPoints contains one data point per step, including steps without any statistics
+	DataPoints []GetTimeseriesResponseDataPoint `json:"dataPoints" bson:"dataPoints"`
+}
+
+// GetTimeseriesResponseDataPoint godoc
+type GetTimeseriesResponseDataPoint struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// Values contains the value of each selected counter
+	Values map[string]int `json:"values" bson:"values"`
+}
+
+// ParseTimeseriesCounters returns the counters of a comma-separated list. If the list is empty, all counters are returned
+func ParseTimeseriesCounters(counters string) ([]string, error) {
+	if strings.TrimSpace(counters) == "" {
+		return TimeseriesCounters, nil
+	}
+	result := []string{}
+	for _, counter := range strings.Split(counters, ",") {
+		counter = strings.TrimSpace(counter)
+		if !isTimeseriesCounter(counter) {
+			return nil, fmt.Errorf("unsupported counter '%s'. Supported counters are: %v", counter, TimeseriesCounters)
+		}
+		result = append(result, counter)
+	}
+	return result, nil
+}
+
+func isTimeseriesCounter(counter string) bool {
+	for _, supported := range TimeseriesCounters {
+		if counter == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTimeseries creates the data points for the time frame with zero values for all counters
+func NewTimeseries(from, to time.Time, step string, counters []string) (*GetTimeseriesResponse, error) {
+	next, ok := timeseriesSteps[step]
+	if !ok {
+		return nil, fmt.Errorf("unsupported step '%s'. Supported steps are: 1h, 1d, 1w, 1M", step)
+	}
+	result := &GetTimeseriesResponse{
+		From:       from,
+		To:         to,
+		Step:       step,
+		DataPoints: []GetTimeseriesResponseDataPoint{},
+	}
+	for index := 0; next(from, index).Before(to); index++ {
+		start := next(from, index)
+		if len(result.DataPoints) == MaxTimeseriesDataPoints {
+			return nil, fmt.Errorf("the time frame contains more than %d steps of %s", MaxTimeseriesDataPoints, step)
+		}
+		end := next(from, index+1)
+		if end.After(to) {
+			end = to
+		}
+		values := map[string]int{}
+		for _, counter := range counters {
+			values[counter] = 0
+		}
+		result.DataPoints = append(result.DataPoints, GetTimeseriesResponseDataPoint{
+			From:   start,
+			To:     end,
+			Values: values,
+		})
+	}
+	return result, nil
+}
+
+// AddStatistics adds the counts of the buckets to the data points that contain the start of the buckets.
+// Buckets that start before the time frame are added to the first data point, and buckets that start after it to the last one
+func (t *GetTimeseriesResponse) AddStatistics(buckets []Statistics) {
+	if len(t.DataPoints) == 0 {
+		return
+	}
+	uniqueSequences := make([]*HyperLogLog, len(t.DataPoints))
+	for _, bucket := range buckets {
+		index := t.getDataPointIndex(bucket.From)
+		dataPoint := t.DataPoints[index]
+		for _, project := range bucket.Projects {
+			for _, service := range project.Services {
+				for counter := range dataPoint.Values {
+					switch counter {
+					case TimeseriesCounterEvents:
+						for _, count := range service.Events {
+							dataPoint.Values[counter] = dataPoint.Values[counter] + count
+						}
+					case TimeseriesCounterKeptnServiceExecutions:
+						for _, keptnService := range service.KeptnServiceExecutions {
+							for _, count := range keptnService.Executions {
+								dataPoint.Values[counter] = dataPoint.Values[counter] + count
+							}
+						}
+					case TimeseriesCounterExecutedSequences:
+						dataPoint.Values[counter] = dataPoint.Values[counter] + service.ExecutedSequences
+					case TimeseriesCounterUniqueSequences:
+						// distinct Keptn contexts can not be summed up, so the sketches of the services are merged
+						if uniqueSequences[index] == nil {
+							uniqueSequences[index] = NewHyperLogLog()
+						}
+						uniqueSequences[index].Merge(service.UniqueSequences)
+					}
+				}
+			}
+		}
+	}
+	for index, sketch := range uniqueSequences {
+		if sketch != nil {
+			t.DataPoints[index].Values[TimeseriesCounterUniqueSequences] = sketch.Count()
+		}
+	}
+}
+
+func (t *GetTimeseriesResponse) getDataPointIndex(start time.Time) int {
+	for index, dataPoint := range t.DataPoints {
+		if start.Before(dataPoint.To) {
+			return index
+		}
+	}
+	return len(t.DataPoints) - 1
+}
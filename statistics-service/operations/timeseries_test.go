@@ -0,0 +1,105 @@
+package operations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimeseries(t *testing.T) {
+	from := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	got, err := NewTimeseries(from, from.AddDate(0, 2, 0), "1M", []string{TimeseriesCounterEvents})
+	if err != nil {
+		t.Fatalf("NewTimeseries(): unexpected error: %s", err.Error())
+	}
+	if len(got.DataPoints) != 2 {
+		t.Fatalf("NewTimeseries(): want %d data points, got %d", 2, len(got.DataPoints))
+	}
+	if !got.DataPoints[1].To.Equal(from.AddDate(0, 2, 0)) {
+		t.Errorf("NewTimeseries(): last data point must end at 'to', got %v", got.DataPoints[1].To)
+	}
+	if value, ok := got.DataPoints[0].Values[TimeseriesCounterEvents]; !ok || value != 0 {
+		t.Errorf("NewTimeseries(): empty data points must contain a value of zero, got %v", got.DataPoints[0].Values)
+	}
+
+	// a partial step at the end of the time frame is shortened
+	got, _ = NewTimeseries(from, from.Add(90*time.Minute), "1h", []string{TimeseriesCounterEvents})
+	if len(got.DataPoints) != 2 || !got.DataPoints[1].To.Equal(from.Add(90*time.Minute)) {
+		t.Errorf("NewTimeseries(): unexpected data points for partial step: %v", got.DataPoints)
+	}
+
+	if _, err := NewTimeseries(from, from.AddDate(1, 0, 0), "1h", TimeseriesCounters); err == nil {
+		t.Error("NewTimeseries(): expected an error for too many data points")
+	}
+	if _, err := NewTimeseries(from, from.AddDate(0, 0, 1), "2h", TimeseriesCounters); err == nil {
+		t.Error("NewTimeseries(): expected an error for an unsupported step")
+	}
+}
+
+func TestNewTimeseries_Months(t *testing.T) {
+	from := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	got, err := NewTimeseries(from, time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC), "1M", []string{TimeseriesCounterEvents})
+	if err != nil {
+		t.Fatalf("NewTimeseries(): unexpected error: %s", err.Error())
+	}
+	// months without a 31st day end on their last day, and the following steps start on the 31st again
+	want := []time.Time{
+		time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(got.DataPoints) != len(want)-1 {
+		t.Fatalf("NewTimeseries(): want %d data points, got %d", len(want)-1, len(got.DataPoints))
+	}
+	for index, dataPoint := range got.DataPoints {
+		if !dataPoint.From.Equal(want[index]) || !dataPoint.To.Equal(want[index+1]) {
+			t.Errorf("NewTimeseries(): data point %d: want %v - %v, got %v - %v", index, want[index], want[index+1], dataPoint.From, dataPoint.To)
+		}
+	}
+}
+
+func TestParseTimeseriesCounters(t *testing.T) {
+	if got, _ := ParseTimeseriesCounters(""); len(got) != len(TimeseriesCounters) {
+		t.Errorf("ParseTimeseriesCounters(): want all counters, got %v", got)
+	}
+	if got, _ := ParseTimeseriesCounters("events, uniqueSequences"); len(got) != 2 || got[1] != TimeseriesCounterUniqueSequences {
+		t.Errorf("ParseTimeseriesCounters(): unexpected counters %v", got)
+	}
+	if _, err := ParseTimeseriesCounters("events,unknown"); err == nil {
+		t.Error("ParseTimeseriesCounters(): expected an error for an unknown counter")
+	}
+}
+
+func TestGetTimeseriesResponse_AddStatistics(t *testing.T) {
+	from := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	newBucket := func(start time.Time, keptnContext string) Statistics {
+		bucket := Statistics{From: start, To: start.Add(30 * time.Minute)}
+		bucket.IncreaseEventTypeCount("my-project", "dev", "carts", "my-type", 2)
+		bucket.IncreaseKeptnServiceExecutionCount("my-project", "dev", "carts", "helm-service", "deployment", 1)
+		bucket.AddUniqueSequence("my-project", "dev", "carts", keptnContext)
+		return bucket.WithProjectTotals()
+	}
+
+	timeseries, _ := NewTimeseries(from, from.Add(3*time.Hour), "1h", TimeseriesCounters)
+	timeseries.AddStatistics([]Statistics{
+		newBucket(from, "context-1"),
+		newBucket(from.Add(30*time.Minute), "context-1"),
+		newBucket(from.Add(2*time.Hour), "context-2"),
+	})
+
+	want := []map[string]int{
+		{TimeseriesCounterEvents: 4, TimeseriesCounterKeptnServiceExecutions: 2, TimeseriesCounterExecutedSequences: 0, TimeseriesCounterUniqueSequences: 1},
+		{TimeseriesCounterEvents: 0, TimeseriesCounterKeptnServiceExecutions: 0, TimeseriesCounterExecutedSequences: 0, TimeseriesCounterUniqueSequences: 0},
+		{TimeseriesCounterEvents: 2, TimeseriesCounterKeptnServiceExecutions: 1, TimeseriesCounterExecutedSequences: 0, TimeseriesCounterUniqueSequences: 1},
+	}
+	for index, dataPoint := range timeseries.DataPoints {
+		for counter, value := range want[index] {
+			if dataPoint.Values[counter] != value {
+				t.Errorf("AddStatistics(): data point %d: want %d %s, got %d", index, value, counter, dataPoint.Values[counter])
+			}
+		}
+	}
+}
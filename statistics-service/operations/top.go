@@ -0,0 +1,203 @@
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TopByProject godoc
+const TopByProject = "project"
+
+// TopByService godoc
+const TopByService = "service"
+
+// TopByKeptnService godoc
+const TopByKeptnService = "keptnService"
+
+// TopByEventType godoc
+const TopByEventType = "eventType"
+
+// TopMetricExecutions counts the Keptn service executions
+const TopMetricExecutions = "executions"
+
+// TopMetricEvents counts the received events
+const TopMetricEvents = "events"
+
+// TopMetricSequences counts the executed sequences
+const TopMetricSequences = "sequences"
+
+// DefaultTopN is the number of entries returned if n is not set
+const DefaultTopN = 10
+
+// GetTopParams godoc
+type GetTopParams struct {
+	GetStatisticsParams
+	// By contains the kind of the ranked entities, i.e. project, service, keptnService or eventType
+	By string `form:"by" json:"by"`
+	// N contains the maximum number of entries
+	N int `form:"n" json:"n"`
+	// Metric contains the counter used for the ranking, i.e. executions, events or sequences
+	Metric string `form:"metric" json:"metric"`
+}
+
+// Validate checks the parameters and sets the defaults of n and metric
+func (p *GetTopParams) Validate() error {
+	switch p.By {
+	case TopByProject, TopByService, TopByKeptnService, TopByEventType:
+	default:
+		return fmt.Errorf("unsupported value '%s' of 'by'. Supported values are: %s, %s, %s, %s", p.By, TopByProject, TopByService, TopByKeptnService, TopByEventType)
+	}
+	if p.Metric == "" {
+		p.Metric = TopMetricExecutions
+	}
+	switch p.Metric {
+	case TopMetricExecutions, TopMetricEvents:
+	case TopMetricSequences:
+		// sequences are executed by the shipyard-controller, so they are not attributed to Keptn services
+		if p.By == TopByKeptnService {
+			return fmt.Errorf("metric '%s' is not supported for '%s'", TopMetricSequences, TopByKeptnService)
+		}
+	default:
+		return fmt.Errorf("unsupported metric '%s'. Supported metrics are: %s, %s, %s", p.Metric, TopMetricExecutions, TopMetricEvents, TopMetricSequences)
+	}
+	if p.N < 0 {
+		return fmt.Errorf("'n' must be a positive number")
+	}
+	if p.N == 0 {
+		p.N = DefaultTopN
+	}
+	return nil
+}
+
+// GetTopResponse godoc
+type GetTopResponse struct {
+	// From godoc
+	From time.Time `json:"from" bson:"from"`
+	// To godoc
+	To time.Time `json:"to" bson:"to"`
+	// By godoc
+	By string `json:"by" bson:"by"`
+	// Metric godoc
+	Metric string `json:"metric" bson:"metric"`
+	// Total contains the sum of the metric over all entities, including the ones that are not part of the top entries
+	Total int `json:"total" bson:"total"`
+	// Entries godoc
+	Entries []GetTopResponseEntry `json:"entries" bson:"entries"`
+}
+
+// GetTopResponseEntry godoc
+type GetTopResponseEntry struct {
+	// Rank starts at 1
+	Rank int `json:"rank" bson:"rank"`
+	// Name godoc
+	Name string `json:"name" bson:"name"`
+	// Project is only set when ranking services, since service names are only unique within a project
+	Project string `json:"project,omitempty" bson:"project,omitempty"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+	// Share contains the ratio of the count to the total
+	Share float64 `json:"share" bson:"share"`
+}
+
+type topEntityKey struct {
+	project string
+	name    string
+}
+
+// Top returns the n entities with the highest value of the metric. Entities with the same value are ordered by name
+func (s Statistics) Top(by, metric string, n int) GetTopResponse {
+	counts := map[topEntityKey]int{}
+	for projectName, project := range s.Projects {
+		if by == TopByKeptnService && metric == TopMetricEvents {
+			// the events sent by Keptn services are only tracked by the inventory
+			for keptnServiceName, keptnService := range project.KeptnServices {
+				for _, version := range keptnService.Versions {
+					counts[topEntityKey{name: keptnServiceName}] += version.Events
+				}
+			}
+			continue
+		}
+		for serviceName, service := range project.Services {
+			switch by {
+			case TopByProject:
+				counts[topEntityKey{name: projectName}] += service.count(metric)
+			case TopByService:
+				counts[topEntityKey{project: projectName, name: serviceName}] += service.count(metric)
+			case TopByKeptnService:
+				for keptnServiceName, keptnService := range service.KeptnServiceExecutions {
+					for _, count := range keptnService.Executions {
+						counts[topEntityKey{name: keptnServiceName}] += count
+					}
+				}
+			case TopByEventType:
+				for eventType, count := range service.countsPerType(metric) {
+					counts[topEntityKey{name: eventType}] += count
+				}
+			}
+		}
+	}
+
+	result := GetTopResponse{
+		By:      by,
+		Metric:  metric,
+		Entries: []GetTopResponseEntry{},
+	}
+	for key, count := range counts {
+		if count == 0 {
+			continue
+		}
+		result.Total += count
+		result.Entries = append(result.Entries, GetTopResponseEntry{
+			Name:    key.name,
+			Project: key.project,
+			Count:   count,
+		})
+	}
+	sort.Slice(result.Entries, func(i, j int) bool {
+		if result.Entries[i].Count != result.Entries[j].Count {
+			return result.Entries[i].Count > result.Entries[j].Count
+		}
+		if result.Entries[i].Project != result.Entries[j].Project {
+			return result.Entries[i].Project < result.Entries[j].Project
+		}
+		return result.Entries[i].Name < result.Entries[j].Name
+	})
+	if len(result.Entries) > n {
+		result.Entries = result.Entries[:n]
+	}
+	for index := range result.Entries {
+		result.Entries[index].Rank = index + 1
+		result.Entries[index].Share = float64(result.Entries[index].Count) / float64(result.Total)
+	}
+	return result
+}
+
+// count returns the value of the metric for the service
+func (svc *Service) count(metric string) int {
+	if metric == TopMetricSequences {
+		return svc.ExecutedSequences
+	}
+	count := 0
+	for _, value := range svc.countsPerType(metric) {
+		count += value
+	}
+	return count
+}
+
+// countsPerType returns the value of the metric per event type, task or sequence
+func (svc *Service) countsPerType(metric string) map[string]int {
+	switch metric {
+	case TopMetricEvents:
+		return svc.Events
+	case TopMetricSequences:
+		return svc.ExecutedSequencesPerType
+	}
+	result := map[string]int{}
+	for _, keptnService := range svc.KeptnServiceExecutions {
+		for eventType, count := range keptnService.Executions {
+			result[eventType] += count
+		}
+	}
+	return result
+}
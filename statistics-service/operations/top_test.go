@@ -0,0 +1,65 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestStatistics_Top(t *testing.T) {
+	statistics := Statistics{}
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.deployment.finished", 5)
+	statistics.IncreaseEventTypeCount("sockshop", "dev", "carts", "sh.keptn.event.test.finished", 1)
+	statistics.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "helm-service", "deployment", 5)
+	statistics.IncreaseKeptnServiceExecutionCount("sockshop", "dev", "carts", "jmeter-service", "test", 1)
+	statistics.IncreaseEventTypeCount("sockshop", "production", "carts-db", "sh.keptn.event.deployment.finished", 2)
+	statistics.IncreaseKeptnServiceExecutionCount("sockshop", "production", "carts-db", "helm-service", "deployment", 2)
+	statistics.IncreaseExecutedSequencesCount("sockshop", "production", "carts-db", 1)
+	statistics.IncreaseEventTypeCount("podtato-head", "dev", "carts", "sh.keptn.event.deployment.finished", 2)
+	statistics.IncreaseKeptnServiceExecutionCount("podtato-head", "dev", "carts", "helm-service", "deployment", 2)
+	statistics = statistics.WithProjectTotals()
+
+	got := statistics.Top(TopByService, TopMetricExecutions, 2)
+	if got.Total != 10 || len(got.Entries) != 2 {
+		t.Fatalf("Top(): unexpected result %+v", got)
+	}
+	if got.Entries[0].Name != "carts" || got.Entries[0].Project != "sockshop" || got.Entries[0].Count != 6 || got.Entries[0].Share != 0.6 || got.Entries[0].Rank != 1 {
+		t.Errorf("Top(): unexpected first entry %+v", got.Entries[0])
+	}
+	// entries with the same count are ordered by project and name
+	if got.Entries[1].Project != "podtato-head" || got.Entries[1].Rank != 2 {
+		t.Errorf("Top(): unexpected second entry %+v", got.Entries[1])
+	}
+
+	got = statistics.Top(TopByKeptnService, TopMetricExecutions, 10)
+	if len(got.Entries) != 2 || got.Entries[0].Name != "helm-service" || got.Entries[0].Count != 9 {
+		t.Errorf("Top(): unexpected Keptn services %+v", got.Entries)
+	}
+
+	got = statistics.Top(TopByEventType, TopMetricEvents, 10)
+	if len(got.Entries) != 2 || got.Entries[0].Name != "sh.keptn.event.deployment.finished" || got.Entries[0].Count != 9 {
+		t.Errorf("Top(): unexpected event types %+v", got.Entries)
+	}
+
+	// entities without any sequences are not ranked
+	got = statistics.Top(TopByProject, TopMetricSequences, 10)
+	if len(got.Entries) != 1 || got.Entries[0].Name != "sockshop" || got.Entries[0].Share != 1 {
+		t.Errorf("Top(): unexpected projects %+v", got.Entries)
+	}
+}
+
+func TestGetTopParams_Validate(t *testing.T) {
+	params := &GetTopParams{By: TopByProject}
+	if err := params.Validate(); err != nil || params.N != DefaultTopN || params.Metric != TopMetricExecutions {
+		t.Errorf("Validate(): unexpected defaults %+v, %v", params, err)
+	}
+	invalid := []GetTopParams{
+		{By: "stage"},
+		{By: TopByProject, Metric: "durations"},
+		{By: TopByKeptnService, Metric: TopMetricSequences},
+		{By: TopByProject, N: -1},
+	}
+	for _, params := range invalid {
+		if err := params.Validate(); err == nil {
+			t.Errorf("Validate(): expected an error for %+v", params)
+		}
+	}
+}
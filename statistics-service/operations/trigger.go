@@ -0,0 +1,91 @@
+package operations
+
+import (
+	"strings"
+)
+
+// TriggerCategoryCLI godoc
+const TriggerCategoryCLI = "cli"
+
+// TriggerCategoryAPI godoc
+const TriggerCategoryAPI = "api"
+
+// TriggerCategoryBridge godoc
+const TriggerCategoryBridge = "bridge"
+
+// TriggerCategoryMonitoring denotes sequences that have been triggered by a monitoring integration, e.g. by sending a problem
+const TriggerCategoryMonitoring = "monitoring"
+
+// TriggerCategoryCustom denotes sequences that have been triggered by any other service
+const TriggerCategoryCustom = "custom"
+
+// monitoringSources contains parts of the sources of known monitoring integrations
+var monitoringSources = []string{"dynatrace", "prometheus", "alertmanager"}
+
+// GetTriggerCategory returns how a sequence has been started, based on the source and the type of its triggering event
+func GetTriggerCategory(source, triggerType string) string {
+	source = strings.ToLower(source)
+	switch {
+	case source == "cli" || strings.Contains(source, "keptn-cli") || strings.HasSuffix(source, "keptn/keptn/cli"):
+		return TriggerCategoryCLI
+	case source == "api" || strings.HasSuffix(source, "keptn/keptn/api"):
+		return TriggerCategoryAPI
+	case strings.Contains(source, "bridge"):
+		return TriggerCategoryBridge
+	case triggerType == "problem":
+		return TriggerCategoryMonitoring
+	}
+	for _, monitoringSource := range monitoringSources {
+		if strings.Contains(source, monitoringSource) {
+			return TriggerCategoryMonitoring
+		}
+	}
+	return TriggerCategoryCustom
+}
+
+// GetStatisticsResponseTrigger godoc
+type GetStatisticsResponseTrigger struct {
+	// Type contains the type of the triggering event, e.g. delivery, evaluation or problem
+	Type string `json:"type" bson:"type"`
+	// Source contains the source of the triggering event
+	Source string `json:"source" bson:"source"`
+	// Category contains the kind of the source, i.e. cli, api, bridge, monitoring or custom
+	Category string `json:"category" bson:"category"`
+	// Count godoc
+	Count int `json:"count" bson:"count"`
+}
+
+// ConvertToGetStatisticsResponseTriggers converts the number of triggered sequences per trigger type and source into their API representation
+func ConvertToGetStatisticsResponseTriggers(triggers map[string]map[string]int) []GetStatisticsResponseTrigger {
+	if len(triggers) == 0 {
+		return nil
+	}
+	result := []GetStatisticsResponseTrigger{}
+	for triggerType, sources := range triggers {
+		for source, count := range sources {
+			result = append(result, GetStatisticsResponseTrigger{
+				Type:     triggerType,
+				Source:   source,
+				Category: GetTriggerCategory(source, triggerType),
+				Count:    count,
+			})
+		}
+	}
+	return result
+}
+
+// MergeTriggers adds the number of triggered sequences per trigger type and source to the target
+func MergeTriggers(target, triggers map[string]map[string]int) map[string]map[string]int {
+	if target == nil {
+		target = map[string]map[string]int{}
+	}
+	for triggerType, sources := range triggers {
+		if target[triggerType] == nil {
+			target[triggerType] = map[string]int{}
+		}
+		for source, count := range sources {
+			target[triggerType][source] = target[triggerType][source] + count
+		}
+	}
+	return target
+}
@@ -0,0 +1,29 @@
+package operations
+
+import (
+	"testing"
+)
+
+func TestGetTriggerCategory(t *testing.T) {
+	tests := []struct {
+		source      string
+		triggerType string
+		want        string
+	}{
+		{source: "https://github.com/keptn/keptn/cli", triggerType: "delivery", want: TriggerCategoryCLI},
+		{source: "keptn-cli", triggerType: "configuration-change", want: TriggerCategoryCLI},
+		{source: "https://github.com/keptn/keptn/api", triggerType: "delivery", want: TriggerCategoryAPI},
+		{source: "api", triggerType: "evaluation", want: TriggerCategoryAPI},
+		{source: "https://github.com/keptn/keptn/bridge", triggerType: "evaluation", want: TriggerCategoryBridge},
+		{source: "dynatrace-service", triggerType: "remediation", want: TriggerCategoryMonitoring},
+		{source: "my-alerting-service", triggerType: "problem", want: TriggerCategoryMonitoring},
+		{source: "my-ci-service", triggerType: "delivery", want: TriggerCategoryCustom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source+"/"+tt.triggerType, func(t *testing.T) {
+			if got := GetTriggerCategory(tt.source, tt.triggerType); got != tt.want {
+				t.Errorf("GetTriggerCategory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}